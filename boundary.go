@@ -0,0 +1,88 @@
+package dateutil
+
+import "time"
+
+// TimeUnit the unit of 'StartOf' and 'EndOf'
+type TimeUnit int
+
+const (
+	// UnitDay the day
+	UnitDay TimeUnit = iota
+	// UnitWeek the week, the first day of the week is monday by default, can be changed by 'WithWeekStart'
+	UnitWeek
+	// UnitMonth the month
+	UnitMonth
+	// UnitQuarter the quarter, January, April, July and October are the first months
+	UnitQuarter
+	// UnitHalfYear the half year, January and July are the first months
+	UnitHalfYear
+	// UnitYear the year
+	UnitYear
+	// UnitDecade the decade, e.g. from 2020 to 2029
+	UnitDecade
+)
+
+// StartOf get the first instant of the unit which the time is in, in the location of the time
+// if the midnight is skipped by the daylight saving time, the first existing instant of the day will be used
+func StartOf(t time.Time, unit TimeUnit, opts ...Option) time.Time {
+	o := makeOptions(opts)
+	year, month, day := t.Date()
+	switch unit {
+	case UnitWeek:
+		if o.weekStart == time.Monday {
+			// the ISO-8601 week, the same week as 'W' of 'DateFormat'
+			isoYear, week := t.ISOWeek()
+			year, month, day = isoWeekStart(isoYear, week, time.UTC).Date()
+		} else {
+			day -= (int(t.Weekday()) - int(o.weekStart) + 7) % 7
+		}
+	case UnitMonth:
+		day = 1
+	case UnitQuarter:
+		month, day = (month-1)/3*3+1, 1
+	case UnitHalfYear:
+		month, day = (month-1)/6*6+1, 1
+	case UnitYear:
+		month, day = time.January, 1
+	case UnitDecade:
+		year, month, day = year-floorMod(year, 10), time.January, 1
+	}
+	return startOfDay(year, month, day, t.Location())
+}
+
+// EndOf get the last instant of the unit which the time is in, e.g. "2021-09-30 23:59:59.999999999"
+func EndOf(t time.Time, unit TimeUnit, opts ...Option) time.Time {
+	start := StartOf(t, unit, opts...)
+	year, month, day := start.Date()
+	// the last day of the unit, the last month's days are the same as 't' of 'DateFormat'
+	switch unit {
+	case UnitWeek:
+		day += 6
+	case UnitMonth:
+		day = daysInMonth(year, month)
+	case UnitQuarter:
+		month += 2
+		day = daysInMonth(year, month)
+	case UnitHalfYear:
+		month += 5
+		day = daysInMonth(year, month)
+	case UnitYear:
+		month, day = time.December, daysInMonth(year, time.December)
+	case UnitDecade:
+		year, month, day = year+9, time.December, daysInMonth(year+9, time.December)
+	}
+	return startOfDay(year, month, day+1, t.Location()).Add(-time.Nanosecond)
+}
+
+// get the first instant of the day, the day can overflow like 'time.Date'
+func startOfDay(year int, month time.Month, day int, location *time.Location) time.Time {
+	year, month, day = time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Date()
+	t := time.Date(year, month, day, 0, 0, 0, 0, location)
+	if t.Day() != day {
+		// the midnight doesn't exist, move to the end of the skipped time
+		_, offset := t.Zone()
+		_, noonOffset := time.Date(year, month, day, 12, 0, 0, 0, location).Zone()
+		t = t.Add(time.Duration(noonOffset-offset) * time.Second)
+	}
+	return t
+}
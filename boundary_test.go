@@ -0,0 +1,58 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartOfEndOf(t *testing.T) {
+	layout := "2006-01-02 15:04:05.999999999"
+	// Sunday, 2021-09-05
+	date := time.Date(2021, time.September, 5, 18, 7, 6, 12345678, time.UTC)
+	cases := []struct {
+		unit       TimeUnit
+		start, end string
+	}{
+		{UnitDay, "2021-09-05 00:00:00", "2021-09-05 23:59:59.999999999"},
+		{UnitWeek, "2021-08-30 00:00:00", "2021-09-05 23:59:59.999999999"},
+		{UnitMonth, "2021-09-01 00:00:00", "2021-09-30 23:59:59.999999999"},
+		{UnitQuarter, "2021-07-01 00:00:00", "2021-09-30 23:59:59.999999999"},
+		{UnitHalfYear, "2021-07-01 00:00:00", "2021-12-31 23:59:59.999999999"},
+		{UnitYear, "2021-01-01 00:00:00", "2021-12-31 23:59:59.999999999"},
+		{UnitDecade, "2020-01-01 00:00:00", "2029-12-31 23:59:59.999999999"},
+	}
+	for _, cur := range cases {
+		assert.Equal(t, StartOf(date, cur.unit).Format(layout), cur.start)
+		assert.Equal(t, EndOf(date, cur.unit).Format(layout), cur.end)
+	}
+	// the first day of the week
+	sunday := WithWeekStart(time.Sunday)
+	assert.Equal(t, StartOf(date, UnitWeek, sunday).Format(layout), "2021-09-05 00:00:00")
+	assert.Equal(t, EndOf(date, UnitWeek, sunday).Format(layout), "2021-09-11 23:59:59.999999999")
+	saturday := date.AddDate(0, 0, -1)
+	assert.Equal(t, StartOf(saturday, UnitWeek, WithWeekStart(time.Saturday)).Format(layout), "2021-09-04 00:00:00")
+	// the weeks across the years are the ISO-8601 weeks of 'W', the days of the months are the same as 't'
+	newYear := time.Date(2021, time.January, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, StartOf(newYear, UnitWeek).Format(layout), "2020-12-28 00:00:00")
+	assert.Equal(t, EndOf(newYear, UnitWeek).Format(layout), "2021-01-03 23:59:59.999999999")
+	if week, err := DateFormat(StartOf(newYear, UnitWeek), "W"); err == nil {
+		assert.Equal(t, week, "53")
+	}
+	leap := time.Date(2020, time.February, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, EndOf(leap, UnitMonth).Format(layout), "2020-02-29 23:59:59.999999999")
+	assert.Equal(t, EndOf(leap, UnitQuarter).Format(layout), "2020-03-31 23:59:59.999999999")
+	// keep the location
+	assert.Equal(t, StartOf(date.In(localLocation), UnitDay).Location(), localLocation)
+	// daylight saving time
+	newYork, _ := time.LoadLocation("America/New_York")
+	dst := time.Date(2021, time.March, 14, 12, 0, 0, 0, newYork)
+	assert.Equal(t, EndOf(dst, UnitDay).Sub(StartOf(dst, UnitDay)), 23*time.Hour-time.Nanosecond)
+	assert.Equal(t, EndOf(dst, UnitMonth).Format(layout+" MST"), "2021-03-31 23:59:59.999999999 EDT")
+	// the midnight is skipped
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	skipped := time.Date(2018, time.November, 4, 12, 0, 0, 0, saoPaulo)
+	assert.Equal(t, StartOf(skipped, UnitDay).Format(layout+" -0700"), "2018-11-04 01:00:00 -0200")
+	assert.Equal(t, EndOf(skipped.AddDate(0, 0, -1), UnitDay).Format(layout+" -0700"), "2018-11-03 23:59:59.999999999 -0300")
+}
@@ -0,0 +1,237 @@
+package dateutil
+
+import (
+	"sync"
+	"time"
+)
+
+// Calendar decide which days are the business days
+type Calendar interface {
+	IsBusinessDay(t time.Time) bool
+}
+
+// WeekdayCalendar the default calendar, the business days are from monday to friday
+type WeekdayCalendar struct{}
+
+// IsBusinessDay check if the day is not saturday or sunday
+func (WeekdayCalendar) IsBusinessDay(t time.Time) bool {
+	weekday := t.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// HolidayRule get the holiday of a year
+type HolidayRule interface {
+	// Holiday get the date of the holiday in the year at midnight in UTC, false if there is no holiday in the year
+	Holiday(year int) (time.Time, bool)
+}
+
+// FixedHoliday the holiday in the same day every year, e.g. "12-25"
+type FixedHoliday struct {
+	Month time.Month
+	Day   int
+}
+
+// Holiday the fixed date of the year, false if the day doesn't exist, e.g. "02-29" in a common year
+func (rule FixedHoliday) Holiday(year int) (time.Time, bool) {
+	if rule.Day < 1 || rule.Day > daysInMonth(year, rule.Month) {
+		return time.Time{}, false
+	}
+	return time.Date(year, rule.Month, rule.Day, 0, 0, 0, 0, time.UTC), true
+}
+
+// NthWeekdayHoliday the nth weekday of the month, e.g. the third monday of january
+type NthWeekdayHoliday struct {
+	Month   time.Month
+	Weekday time.Weekday
+	// the nth weekday from 1, the negative number counts from the end of the month, -1 is the last weekday
+	N int
+}
+
+// Holiday the nth weekday of the month, false if the month doesn't have the nth weekday
+func (rule NthWeekdayHoliday) Holiday(year int) (time.Time, bool) {
+	days := daysInMonth(year, rule.Month)
+	var day int
+	switch {
+	case rule.N > 0:
+		firstWeekday := int(time.Date(year, rule.Month, 1, 0, 0, 0, 0, time.UTC).Weekday())
+		day = 1 + (int(rule.Weekday)-firstWeekday+7)%7 + (rule.N-1)*7
+	case rule.N < 0:
+		lastWeekday := int(time.Date(year, rule.Month, days, 0, 0, 0, 0, time.UTC).Weekday())
+		day = days - (lastWeekday-int(rule.Weekday)+7)%7 + (rule.N+1)*7
+	}
+	if day < 1 || day > days {
+		return time.Time{}, false
+	}
+	return time.Date(year, rule.Month, day, 0, 0, 0, 0, time.UTC), true
+}
+
+// EasterHoliday the days relative to the easter sunday, e.g. -2 is the good friday, 1 is the easter monday
+type EasterHoliday struct {
+	Offset int
+	// use the orthodox easter instead of the western easter
+	Orthodox bool
+}
+
+// Holiday the easter sunday of the year add the offset days
+func (rule EasterHoliday) Holiday(year int) (time.Time, bool) {
+	if rule.Orthodox {
+		return OrthodoxEaster(year).AddDate(0, 0, rule.Offset), true
+	}
+	return Easter(year).AddDate(0, 0, rule.Offset), true
+}
+
+// Easter get the western easter sunday of the year in the gregorian calendar at midnight in UTC
+func Easter(year int) time.Time {
+	// the anonymous gregorian algorithm
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// HolidayCalendar the business days are the weekdays except the holidays
+type HolidayCalendar struct {
+	// Weekend the days which are not business days, saturday and sunday if empty
+	// the observed days of the holidays are always shifted from saturday and sunday
+	Weekend []time.Weekday
+	// the static holidays
+	dates map[[3]int]bool
+	// the holiday rules evaluated for every year
+	holidays []Holiday
+	// the evaluated days of the holidays by year
+	mutex sync.Mutex
+	years map[int]map[[3]int]bool
+}
+
+// NewHolidayCalendar make a calendar with the static holidays, the holidays can be any type 'DateTime' accepted
+// the date of a time.Time holiday is taken in its own location
+func NewHolidayCalendar(holidays ...interface{}) (*HolidayCalendar, error) {
+	calendar := &HolidayCalendar{
+		dates: map[[3]int]bool{},
+		years: map[int]map[[3]int]bool{},
+	}
+	for _, holiday := range holidays {
+		if t, ok := holiday.(time.Time); ok {
+			calendar.dates[dateKey(t)] = true
+			continue
+		}
+		t, err := DateTime(holiday)
+		if err != nil {
+			return nil, err
+		}
+		calendar.dates[dateKey(t)] = true
+	}
+	return calendar, nil
+}
+
+// AddRules add the holiday rules without names and observed shifting to the calendar
+func (calendar *HolidayCalendar) AddRules(rules ...HolidayRule) *HolidayCalendar {
+	holidays := make([]Holiday, len(rules))
+	for index, rule := range rules {
+		holidays[index] = Holiday{Rule: rule}
+	}
+	return calendar.AddHolidays(holidays...)
+}
+
+// IsHoliday check if the day of the time is a holiday or an observed day of a holiday
+func (calendar *HolidayCalendar) IsHoliday(t time.Time) bool {
+	key := dateKey(t)
+	if calendar.dates[key] {
+		return true
+	}
+	// the observed day may be in the previous or next year
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+	if calendar.years == nil {
+		calendar.years = map[int]map[[3]int]bool{}
+	}
+	for year := key[0] - 1; year <= key[0]+1; year++ {
+		days, ok := calendar.years[year]
+		if !ok {
+			days = map[[3]int]bool{}
+			for _, holiday := range calendar.holidaysOf(year) {
+				days[dateKey(holiday.Date)] = true
+				days[dateKey(holiday.Observed)] = true
+			}
+			calendar.years[year] = days
+		}
+		if days[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// check if the day is a weekend of the calendar
+func (calendar *HolidayCalendar) isWeekend(t time.Time) bool {
+	if len(calendar.Weekend) == 0 {
+		return !(WeekdayCalendar{}).IsBusinessDay(t)
+	}
+	weekday := t.Weekday()
+	for _, cur := range calendar.Weekend {
+		if cur == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusinessDay check if the day is neither a weekend nor a holiday
+func (calendar *HolidayCalendar) IsBusinessDay(t time.Time) bool {
+	return !calendar.isWeekend(t) && !calendar.IsHoliday(t)
+}
+
+// AddBusinessDays move the time by the business days of the calendar, the negative days move backward
+// the clock of the time is kept, the calendar can be nil for the 'WeekdayCalendar'
+func AddBusinessDays(t time.Time, days int, calendar Calendar) time.Time {
+	if calendar == nil {
+		calendar = WeekdayCalendar{}
+	}
+	step := 1
+	if days < 0 {
+		days, step = -days, -1
+	}
+	for days > 0 {
+		t = t.AddDate(0, 0, step)
+		if calendar.IsBusinessDay(t) {
+			days--
+		}
+	}
+	return t
+}
+
+// BusinessDaysBetween count the business days after the day of 'a' until the day of 'b'
+// it's negative if 'b' is before 'a', so 'AddBusinessDays(a, n)' is in the same day of 'b'
+// the calendar can be nil for the 'WeekdayCalendar'
+func BusinessDaysBetween(a, b time.Time, calendar Calendar) int {
+	if calendar == nil {
+		calendar = WeekdayCalendar{}
+	}
+	b = b.In(a.Location())
+	days := daysBetween(a, b)
+	step := 1
+	if days < 0 {
+		days, step = -days, -1
+	}
+	count := 0
+	for i := 1; i <= days; i++ {
+		if calendar.IsBusinessDay(a.AddDate(0, 0, step*i)) {
+			count += step
+		}
+	}
+	return count
+}
+
+// the year, month and day of the time
+func dateKey(t time.Time) [3]int {
+	year, month, day := t.Date()
+	return [3]int{year, int(month), day}
+}
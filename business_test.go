@@ -0,0 +1,109 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolidayRules(t *testing.T) {
+	// easter sundays
+	for year, expect := range map[int]string{
+		2000: "2000-04-23",
+		2019: "2019-04-21",
+		2021: "2021-04-04",
+		2024: "2024-03-31",
+		2038: "2038-04-25",
+	} {
+		assert.Equal(t, Easter(year).Format("2006-01-02"), expect)
+	}
+	rules := []struct {
+		rule   HolidayRule
+		expect string
+	}{
+		{FixedHoliday{time.December, 25}, "2021-12-25"},
+		{NthWeekdayHoliday{time.January, time.Monday, 3}, "2021-01-18"},
+		{NthWeekdayHoliday{time.May, time.Monday, -1}, "2021-05-31"},
+		{NthWeekdayHoliday{time.November, time.Thursday, 4}, "2021-11-25"},
+		{EasterHoliday{Offset: -2}, "2021-04-02"},
+		{EasterHoliday{Offset: 1}, "2021-04-05"},
+	}
+	for _, cur := range rules {
+		if holiday, ok := cur.rule.Holiday(2021); ok {
+			assert.Equal(t, holiday.Format("2006-01-02"), cur.expect)
+		} else {
+			assert.Fail(t, "Holiday "+cur.expect+" fail")
+		}
+	}
+	// no holiday in the year
+	if _, ok := (FixedHoliday{time.February, 29}).Holiday(2021); ok {
+		assert.Fail(t, "FixedHoliday 02-29 in 2021 ok")
+	}
+	if _, ok := (NthWeekdayHoliday{time.February, time.Monday, 5}).Holiday(2021); ok {
+		assert.Fail(t, "NthWeekdayHoliday fifth monday of february ok")
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	// Friday, 2021-09-03
+	friday := time.Date(2021, time.September, 3, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, AddBusinessDays(friday, 1, nil).Format("2006-01-02 15:04"), "2021-09-06 10:00")
+	assert.Equal(t, AddBusinessDays(friday, 5, nil).Format("2006-01-02"), "2021-09-10")
+	assert.Equal(t, AddBusinessDays(friday, -5, nil).Format("2006-01-02"), "2021-08-27")
+	assert.Equal(t, AddBusinessDays(friday, 0, nil), friday)
+	// holidays
+	calendar, err := NewHolidayCalendar("2021-09-07")
+	if err != nil {
+		assert.Fail(t, "NewHolidayCalendar fail")
+		return
+	}
+	calendar.AddRules(NthWeekdayHoliday{time.September, time.Monday, 1})
+	assert.False(t, calendar.IsBusinessDay(time.Date(2021, time.September, 6, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, calendar.IsHoliday(time.Date(2021, time.September, 7, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, AddBusinessDays(friday, 1, calendar).Format("2006-01-02"), "2021-09-08")
+	assert.Equal(t, AddBusinessDays(time.Date(2021, time.September, 8, 0, 0, 0, 0, time.UTC), -1, calendar).Format("2006-01-02"), "2021-09-03")
+	// the weekends
+	calendar.Weekend = []time.Weekday{time.Friday, time.Saturday}
+	assert.False(t, calendar.IsBusinessDay(friday))
+	assert.True(t, calendar.IsBusinessDay(friday.AddDate(0, 0, 2)))
+	calendar.Weekend = nil
+	// days between
+	assert.Equal(t, BusinessDaysBetween(friday, friday.AddDate(0, 0, 7), nil), 5)
+	assert.Equal(t, BusinessDaysBetween(friday.AddDate(0, 0, 7), friday, nil), -5)
+	assert.Equal(t, BusinessDaysBetween(friday, friday, nil), 0)
+	assert.Equal(t, BusinessDaysBetween(friday, friday.AddDate(0, 0, 7), calendar), 3)
+	for _, days := range []int{1, 3, 8, -1, -4, -10} {
+		moved := AddBusinessDays(friday, days, calendar)
+		assert.Equal(t, BusinessDaysBetween(friday, moved, calendar), days)
+	}
+	if _, err := NewHolidayCalendar("wrong date"); err == nil {
+		assert.Fail(t, "NewHolidayCalendar wrong date ok")
+	}
+	// the date of the holiday is taken in its own location rather than the local
+	kiritimati := time.FixedZone("LINT", 14*3600)
+	if zoned, err := NewHolidayCalendar(time.Date(2021, time.December, 24, 0, 0, 0, 0, kiritimati)); err == nil {
+		assert.True(t, zoned.IsHoliday(time.Date(2021, time.December, 24, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, zoned.IsHoliday(time.Date(2021, time.December, 23, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, AddBusinessDays(time.Date(2021, time.December, 23, 0, 0, 0, 0, time.UTC), 1, zoned).Format("2006-01-02"), "2021-12-27")
+	} else {
+		assert.Fail(t, "NewHolidayCalendar zoned holiday fail")
+	}
+	// the relative weekdays
+	utc := WithLocation(time.UTC)
+	if date, err := DateTime("2021-09-03 10:00 +5 weekdays", utc); err == nil {
+		assert.Equal(t, date.Format("2006-01-02 15:04"), "2021-09-10 10:00")
+	} else {
+		assert.Fail(t, "DateTime +5 weekdays fail")
+	}
+	if date, err := DateTime("2021-09-03 1 weekday", utc, WithBusinessCalendar(calendar)); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-09-08")
+	} else {
+		assert.Fail(t, "DateTime 1 weekday with calendar fail")
+	}
+	if date, err := DateTime("2021-09-08 -1 weekday +2 days", utc, WithBusinessCalendar(calendar)); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-09-05")
+	} else {
+		assert.Fail(t, "DateTime -1 weekday +2 days fail")
+	}
+}
@@ -0,0 +1,113 @@
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CalendarSystem convert the dates between a calendar and the gregorian calendar,
+// the years, months and days of the parsed strings and the format characters are in the calendar
+type CalendarSystem interface {
+	// FromGregorian get the year, month and day of the time in the calendar
+	FromGregorian(t time.Time) (year, month, day int)
+	// ToGregorian get the gregorian year, month and day of the date in the calendar,
+	// the day can overflow to the next month
+	ToGregorian(year, month, day int) (int, int, int)
+	// DaysInMonth get the days of the month in the calendar
+	DaysInMonth(year, month int) int
+}
+
+// YearOffsetCalendar the calendar has the same months and days as the gregorian calendar but the year is shifted
+type YearOffsetCalendar struct {
+	// Offset the calendar year minus the gregorian year
+	Offset int
+}
+
+var (
+	// ThaiBuddhistCalendar the thai buddhist era, 2564 is 2021
+	ThaiBuddhistCalendar = YearOffsetCalendar{Offset: 543}
+	// MinguoCalendar the republic of china calendar, "民國110年" is 2021
+	MinguoCalendar = YearOffsetCalendar{Offset: -1911}
+)
+
+// FromGregorian get the shifted year, the month and day of the time
+func (calendar YearOffsetCalendar) FromGregorian(t time.Time) (int, int, int) {
+	year, month, day := t.Date()
+	return year + calendar.Offset, int(month), day
+}
+
+// ToGregorian get the gregorian year, the month and day are not changed
+func (calendar YearOffsetCalendar) ToGregorian(year, month, day int) (int, int, int) {
+	return year - calendar.Offset, month, day
+}
+
+// DaysInMonth get the days of the month of the gregorian year
+func (calendar YearOffsetCalendar) DaysInMonth(year, month int) int {
+	if month < 1 || month > 12 {
+		return 0
+	}
+	return daysInMonth(year-calendar.Offset, time.Month(month))
+}
+
+// the calendar systems have their own month names, e.g. the hijri calendar
+type calendarMonthNames interface {
+	monthName(month int, short bool, locale *Locale) string
+}
+
+// format the year, month and day characters in the calendar system,
+// 'Y' the year without padding, 'y' the last two digits of the year, 'm', 'n', 'd', 'j' and 't' the same as the gregorian ones,
+// 'F' and 'M' the month names if the calendar has its own names
+func formatCalendarChar(calendar CalendarSystem, t time.Time, ch byte, locale *Locale) (string, bool) {
+	year, month, day := calendar.FromGregorian(t)
+	if names, ok := calendar.(calendarMonthNames); ok && (ch == 'F' || ch == 'M') {
+		return names.monthName(month, ch == 'M', locale), true
+	}
+	switch ch {
+	case 'Y':
+		return strconv.Itoa(year), true
+	case 'y':
+		return fmt.Sprintf("%02d", year%100), true
+	case 'm':
+		return fmt.Sprintf("%02d", month), true
+	case 'n':
+		return strconv.Itoa(month), true
+	case 'd':
+		return fmt.Sprintf("%02d", day), true
+	case 'j':
+		return strconv.Itoa(day), true
+	case 't':
+		return strconv.Itoa(calendar.DaysInMonth(year, month)), true
+	}
+	return "", false
+}
+
+// match the CJK date with the short year of the calendar system and the time after it, e.g. "110年9月5日 下午6時"
+func matchCalendarDateTime(t string, locale *Locale) (FormatResult, []*Pattern, error) {
+	t = locale.removeFillers(normalizeWidth(t))
+	pattern, result, loc := factoryMatchFormat("calendar", calendarDateRules, t, locale)
+	if pattern == nil {
+		return nil, nil, fmt.Errorf("wrong calendar date:'%s'", t)
+	}
+	patterns := []*Pattern{pattern}
+	timePattern, ok := matchTimeSuffix(t[loc[1]:], result)
+	if !ok {
+		return nil, nil, fmt.Errorf("wrong time format:'%s'", t)
+	}
+	if timePattern != nil {
+		patterns = append(patterns, timePattern)
+	}
+	return result, patterns, nil
+}
+
+// match the datetime string by the options,
+// the CJK short years like "110年" are only matched with the calendar system, otherwise "5年" is more likely a duration
+func matchOptionsDateTime(t string, o *options) (FormatResult, []*Pattern, error) {
+	lasts, patterns, err := matchDateTime(t, o.locale)
+	if err != nil && o.calendarSystem != nil {
+		if calendarLasts, calendarPatterns, calendarErr := matchCalendarDateTime(t, o.locale); calendarErr == nil {
+			return calendarLasts, calendarPatterns, nil
+		}
+	}
+	return lasts, patterns, err
+}
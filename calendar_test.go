@@ -0,0 +1,71 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarSystem(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	thai, minguo := WithCalendarSystem(ThaiBuddhistCalendar), WithCalendarSystem(MinguoCalendar)
+	cases := []struct {
+		value  string
+		option Option
+		expect string
+	}{
+		{"2564-09-05", thai, "2021-09-05 00:00"},
+		{"5 September 2564 18:07", thai, "2021-09-05 18:07"},
+		{"110-09-05", minguo, "2021-09-05 00:00"},
+		{"99-12-31", minguo, "2010-12-31 00:00"},
+		{"110年9月5日", minguo, "2021-09-05 00:00"},
+		{"110年9月5日 下午6時07分", minguo, "2021-09-05 18:07"},
+		{"2564年9月5日", thai, "2021-09-05 00:00"},
+		// the prefix of the minguo years
+		{"民國110年9月5日 下午6時07分", utc, "2021-09-05 18:07"},
+		{"民国99年12月31日", utc, "2010-12-31 00:00"},
+	}
+	for _, item := range cases {
+		if date, err := DateTime(item.value, utc, item.option); err == nil {
+			assert.Equal(t, date.Format("2006-01-02 15:04"), item.expect, item.value)
+		} else {
+			assert.Fail(t, "DateTime '"+item.value+"' fail")
+		}
+	}
+	// the day of the offsets is clamped to the last day of the month
+	if date, err := DateTime("2567-01-31 +1 month", utc, thai, WithNoOverflow()); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2024-02-29")
+	} else {
+		assert.Fail(t, "DateTime calendar no overflow fail")
+	}
+	if result, err := Parse("民國110年", utc); err == nil {
+		assert.Equal(t, result.Time.Year(), 2021)
+		assert.Equal(t, result.Precision, PrecisionYear)
+	} else {
+		assert.Fail(t, "Parse minguo year fail")
+	}
+	// the conversions
+	date := time.Date(2021, time.September, 5, 18, 7, 6, 0, time.UTC)
+	year, month, day := ThaiBuddhistCalendar.FromGregorian(date)
+	assert.Equal(t, []int{year, month, day}, []int{2564, 9, 5})
+	year, month, day = MinguoCalendar.ToGregorian(110, 2, 29)
+	assert.Equal(t, []int{year, month, day}, []int{2021, 2, 29})
+	assert.Equal(t, ThaiBuddhistCalendar.DaysInMonth(2563, 2), 29)
+	// the format characters
+	if value, err := DateFormat(date, "Y-m-d H:i", thai); err == nil {
+		assert.Equal(t, value, "2564-09-05 18:07")
+	} else {
+		assert.Fail(t, "DateFormat thai fail")
+	}
+	if value, err := DateFormat(date, "民國Y年n月j日", minguo); err == nil {
+		assert.Equal(t, value, "民國110年9月5日")
+	} else {
+		assert.Fail(t, "DateFormat minguo fail")
+	}
+	if value, err := DateFormat("2564-09-05", "y/m/d t", utc, thai); err == nil {
+		assert.Equal(t, value, "64/09/05 30")
+	} else {
+		assert.Fail(t, "DateFormat thai string fail")
+	}
+}
@@ -0,0 +1,517 @@
+package dateutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatDialect the dialect of a date format string
+type FormatDialect int
+
+const (
+	// DialectPHP php's date() format, e.g. "Y-m-d H:i:s"
+	DialectPHP FormatDialect = iota
+	// DialectGo golang's layout, e.g. "2006-01-02 15:04:05"
+	DialectGo
+	// DialectStrftime C-style strftime format, e.g. "%Y-%m-%d %H:%M:%S"
+	DialectStrftime
+	// DialectMoment moment.js/dayjs format, e.g. "YYYY-MM-DD HH:mm:ss"
+	DialectMoment
+	// DialectICU ICU/CLDR pattern, e.g. "yyyy-MM-dd HH:mm:ss"
+	DialectICU
+)
+
+// String the name of the dialect
+func (dialect FormatDialect) String() string {
+	switch dialect {
+	case DialectPHP:
+		return "php"
+	case DialectGo:
+		return "golang"
+	case DialectStrftime:
+		return "strftime"
+	case DialectMoment:
+		return "moment"
+	case DialectICU:
+		return "icu"
+	}
+	return fmt.Sprintf("dialect(%d)", int(dialect))
+}
+
+// the fields of the format tokens, shared by all the dialects
+const (
+	fieldYear           = "year"
+	fieldYearShort      = "year_short"
+	fieldISOYear        = "iso_year"
+	fieldISOYearShort   = "iso_year_short"
+	fieldCentury        = "century"
+	fieldQuarter        = "quarter"
+	fieldMonth          = "month"
+	fieldMonth2         = "month_2"
+	fieldMonthShort     = "month_short"
+	fieldMonthFull      = "month_full"
+	fieldMonthDays      = "month_days"
+	fieldLeapYear       = "leap_year"
+	fieldDay            = "day"
+	fieldDay2           = "day_2"
+	fieldDaySpace       = "day_space"
+	fieldOrdinalSuffix  = "ordinal_suffix"
+	fieldYearDay        = "year_day"
+	fieldYearDay0       = "year_day_0"
+	fieldYearDay3       = "year_day_3"
+	fieldYearDaySpace   = "year_day_space"
+	fieldWeekdayShort   = "weekday_short"
+	fieldWeekdayFull    = "weekday_full"
+	fieldWeekdayMin     = "weekday_min"
+	fieldWeekdayISO     = "weekday_iso"
+	fieldWeekdayNum     = "weekday_num"
+	fieldWeekISO        = "week_iso"
+	fieldWeekISO2       = "week_iso_2"
+	fieldWeekSunday     = "week_sunday"
+	fieldWeekMonday     = "week_monday"
+	fieldHour24         = "hour_24"
+	fieldHour24Pad      = "hour_24_2"
+	fieldHour24Space    = "hour_24_space"
+	fieldHour12         = "hour_12"
+	fieldHour12Pad      = "hour_12_2"
+	fieldHour12Space    = "hour_12_space"
+	fieldHour1To24      = "hour_1_24"
+	fieldHour1To24Pad   = "hour_1_24_2"
+	fieldMinute         = "minute"
+	fieldMinute2        = "minute_2"
+	fieldSecond         = "second"
+	fieldSecond2        = "second_2"
+	fieldAMPMLower      = "ampm_lower"
+	fieldAMPMUpper      = "ampm_upper"
+	fieldTZID           = "tz_id"
+	fieldTZAbbr         = "tz_abbr"
+	fieldTZOffset       = "tz_offset"
+	fieldTZOffsetColon  = "tz_offset_colon"
+	fieldTZOffsetZ      = "tz_offset_z"
+	fieldTZOffsetColonZ = "tz_offset_colon_z"
+	// the offsets of golang's layout only, with the hours or with the seconds
+	fieldTZOffsetHour          = "tz_offset_hour"
+	fieldTZOffsetHourZ         = "tz_offset_hour_z"
+	fieldTZOffsetSeconds       = "tz_offset_seconds"
+	fieldTZOffsetSecondsZ      = "tz_offset_seconds_z"
+	fieldTZOffsetColonSeconds  = "tz_offset_colon_seconds"
+	fieldTZOffsetColonSecondsZ = "tz_offset_colon_seconds_z"
+	fieldTZSeconds             = "tz_seconds"
+	fieldDST                   = "dst"
+	fieldSwatch                = "swatch"
+	fieldUnix                  = "unix"
+	fieldUnixMillis            = "unix_millis"
+	fieldEra                   = "era"
+	// the fraction seconds with n digits, "frac1" ~ "frac9"
+	fieldFracPrefix = "frac"
+)
+
+// formatToken a token of a dialect and its fields
+type formatToken struct {
+	token  string
+	fields []string
+}
+
+// make a token with the fields
+func tok(token string, fields ...string) formatToken {
+	return formatToken{
+		token:  token,
+		fields: fields,
+	}
+}
+
+// make the fraction seconds field
+func fracField(digits int) string {
+	return fmt.Sprintf("%s%d", fieldFracPrefix, digits)
+}
+
+var (
+	// the tokens of the dialects, the first token of the same fields is used when converting to the dialect
+	dialectTokens = map[FormatDialect][]formatToken{
+		DialectPHP: {
+			tok("d", fieldDay2), tok("D", fieldWeekdayShort), tok("j", fieldDay), tok("l", fieldWeekdayFull),
+			tok("N", fieldWeekdayISO), tok("S", fieldOrdinalSuffix), tok("w", fieldWeekdayNum), tok("z", fieldYearDay0),
+			tok("W", fieldWeekISO2), tok("F", fieldMonthFull), tok("m", fieldMonth2), tok("M", fieldMonthShort),
+			tok("n", fieldMonth), tok("t", fieldMonthDays), tok("L", fieldLeapYear), tok("o", fieldISOYear),
+			tok("Y", fieldYear), tok("y", fieldYearShort), tok("a", fieldAMPMLower), tok("A", fieldAMPMUpper),
+			tok("B", fieldSwatch), tok("g", fieldHour12), tok("G", fieldHour24), tok("h", fieldHour12Pad),
+			tok("H", fieldHour24Pad), tok("i", fieldMinute2), tok("s", fieldSecond2), tok("u", fracField(6)),
+			tok("v", fracField(3)), tok("e", fieldTZID), tok("I", fieldDST), tok("O", fieldTZOffset),
+			tok("P", fieldTZOffsetColon), tok("p", fieldTZOffsetColonZ), tok("T", fieldTZAbbr), tok("Z", fieldTZSeconds),
+			tok("U", fieldUnix),
+		},
+		DialectGo: {
+			tok("2006", fieldYear), tok("06", fieldYearShort), tok("January", fieldMonthFull), tok("Jan", fieldMonthShort),
+			tok("01", fieldMonth2), tok("1", fieldMonth), tok("Monday", fieldWeekdayFull), tok("Mon", fieldWeekdayShort),
+			tok("02", fieldDay2), tok("2", fieldDay), tok("_2", fieldDaySpace), tok("002", fieldYearDay3),
+			tok("__2", fieldYearDaySpace), tok("15", fieldHour24Pad), tok("03", fieldHour12Pad), tok("3", fieldHour12),
+			tok("04", fieldMinute2), tok("4", fieldMinute), tok("05", fieldSecond2), tok("5", fieldSecond),
+			tok("PM", fieldAMPMUpper), tok("pm", fieldAMPMLower), tok("MST", fieldTZAbbr), tok("-0700", fieldTZOffset),
+			tok("-07:00", fieldTZOffsetColon), tok("Z0700", fieldTZOffsetZ), tok("Z07:00", fieldTZOffsetColonZ),
+			tok("-07", fieldTZOffsetHour), tok("Z07", fieldTZOffsetHourZ), tok("-070000", fieldTZOffsetSeconds),
+			tok("Z070000", fieldTZOffsetSecondsZ), tok("-07:00:00", fieldTZOffsetColonSeconds),
+			tok("Z07:00:00", fieldTZOffsetColonSecondsZ),
+		},
+		DialectStrftime: {
+			tok("%Y", fieldYear), tok("%y", fieldYearShort), tok("%G", fieldISOYear), tok("%g", fieldISOYearShort),
+			tok("%C", fieldCentury), tok("%m", fieldMonth2), tok("%-m", fieldMonth), tok("%b", fieldMonthShort),
+			tok("%h", fieldMonthShort), tok("%B", fieldMonthFull), tok("%d", fieldDay2), tok("%-d", fieldDay),
+			tok("%e", fieldDaySpace), tok("%_d", fieldDaySpace), tok("%j", fieldYearDay3), tok("%-j", fieldYearDay),
+			tok("%_j", fieldYearDaySpace), tok("%a", fieldWeekdayShort), tok("%A", fieldWeekdayFull),
+			tok("%u", fieldWeekdayISO), tok("%w", fieldWeekdayNum), tok("%V", fieldWeekISO2), tok("%-V", fieldWeekISO),
+			tok("%U", fieldWeekSunday), tok("%W", fieldWeekMonday), tok("%H", fieldHour24Pad), tok("%-H", fieldHour24),
+			tok("%k", fieldHour24Space), tok("%I", fieldHour12Pad), tok("%-I", fieldHour12), tok("%l", fieldHour12Space),
+			tok("%M", fieldMinute2), tok("%-M", fieldMinute), tok("%S", fieldSecond2), tok("%-S", fieldSecond),
+			tok("%p", fieldAMPMUpper), tok("%P", fieldAMPMLower), tok("%f", fracField(6)), tok("%N", fracField(9)),
+			tok("%z", fieldTZOffset), tok("%:z", fieldTZOffsetColon), tok("%Z", fieldTZAbbr), tok("%s", fieldUnix),
+		},
+		DialectMoment: {
+			tok("YYYY", fieldYear), tok("YY", fieldYearShort), tok("GGGG", fieldISOYear), tok("GG", fieldISOYearShort),
+			tok("Q", fieldQuarter), tok("MMMM", fieldMonthFull), tok("MMM", fieldMonthShort), tok("MM", fieldMonth2),
+			tok("M", fieldMonth), tok("Do", fieldDay, fieldOrdinalSuffix), tok("DDDD", fieldYearDay3), tok("DDD", fieldYearDay),
+			tok("DD", fieldDay2), tok("D", fieldDay), tok("dddd", fieldWeekdayFull), tok("ddd", fieldWeekdayShort),
+			tok("dd", fieldWeekdayMin), tok("d", fieldWeekdayNum), tok("E", fieldWeekdayISO), tok("WW", fieldWeekISO2),
+			tok("W", fieldWeekISO), tok("HH", fieldHour24Pad), tok("H", fieldHour24), tok("hh", fieldHour12Pad),
+			tok("h", fieldHour12), tok("kk", fieldHour1To24Pad), tok("k", fieldHour1To24), tok("mm", fieldMinute2),
+			tok("m", fieldMinute), tok("ss", fieldSecond2), tok("s", fieldSecond), tok("A", fieldAMPMUpper),
+			tok("a", fieldAMPMLower), tok("ZZ", fieldTZOffset), tok("Z", fieldTZOffsetColon), tok("z", fieldTZAbbr),
+			tok("zz", fieldTZAbbr), tok("X", fieldUnix), tok("x", fieldUnixMillis),
+		},
+		DialectICU: {
+			tok("yyyy", fieldYear), tok("yy", fieldYearShort), tok("y", fieldYear), tok("YYYY", fieldISOYear),
+			tok("YY", fieldISOYearShort), tok("G", fieldEra), tok("Q", fieldQuarter), tok("MMMM", fieldMonthFull),
+			tok("MMM", fieldMonthShort), tok("MM", fieldMonth2), tok("M", fieldMonth), tok("LLLL", fieldMonthFull),
+			tok("LLL", fieldMonthShort), tok("LL", fieldMonth2), tok("L", fieldMonth), tok("dd", fieldDay2),
+			tok("d", fieldDay), tok("DDD", fieldYearDay3), tok("D", fieldYearDay), tok("EEEE", fieldWeekdayFull),
+			tok("EEE", fieldWeekdayShort), tok("EE", fieldWeekdayShort), tok("E", fieldWeekdayShort), tok("ww", fieldWeekISO2),
+			tok("w", fieldWeekISO), tok("HH", fieldHour24Pad), tok("H", fieldHour24), tok("hh", fieldHour12Pad),
+			tok("h", fieldHour12), tok("kk", fieldHour1To24Pad), tok("k", fieldHour1To24), tok("mm", fieldMinute2),
+			tok("m", fieldMinute), tok("ss", fieldSecond2), tok("s", fieldSecond), tok("a", fieldAMPMUpper),
+			tok("xx", fieldTZOffset), tok("Z", fieldTZOffset), tok("xxx", fieldTZOffsetColon), tok("XX", fieldTZOffsetZ),
+			tok("XXX", fieldTZOffsetColonZ), tok("ZZZZZ", fieldTZOffsetColonZ), tok("z", fieldTZAbbr), tok("zzz", fieldTZAbbr),
+			tok("VV", fieldTZID),
+		},
+	}
+	// the tokens expanded by other tokens
+	dialectComposes = map[FormatDialect]map[string]string{
+		DialectPHP: {
+			"c": "Y-m-d\\TH:i:sP",
+			"r": "D, d M Y H:i:s O",
+		},
+		DialectStrftime: {},
+	}
+	// the tokens sorted by length, used when parsing a format
+	dialectSortedTokens = map[FormatDialect][]formatToken{}
+)
+
+func init() {
+	// the fraction seconds
+	for digits := 1; digits <= 9; digits++ {
+		field := fracField(digits)
+		for _, dialect := range []FormatDialect{DialectMoment, DialectICU} {
+			dialectTokens[dialect] = append(dialectTokens[dialect], tok(strings.Repeat("S", digits), field))
+		}
+	}
+	// strftime's composed directives
+	for directive, compose := range strftimeComposes {
+		dialectComposes[DialectStrftime]["%"+string(directive)] = compose
+	}
+	for dialect, tokens := range dialectTokens {
+		sorted := append([]formatToken{}, tokens...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return len(sorted[i].token) > len(sorted[j].token)
+		})
+		dialectSortedTokens[dialect] = sorted
+	}
+}
+
+// formatUnit a field or a literal of the parsed format
+type formatUnit struct {
+	// the original token
+	token   string
+	field   string
+	literal string
+}
+
+// parse the format into fields and literals
+func parseDialectFormat(format string, dialect FormatDialect) ([]formatUnit, error) {
+	units := []formatUnit{}
+	literal := func(text string) {
+		units = append(units, formatUnit{literal: text})
+	}
+	tokens, ok := dialectSortedTokens[dialect]
+	if !ok {
+		return nil, fmt.Errorf("unknown format dialect '%s'", dialect)
+	}
+	for i := 0; i < len(format); {
+		ch := format[i]
+		// escapes and quotes
+		switch dialect {
+		case DialectPHP:
+			if ch == '\\' && i+1 < len(format) {
+				literal(format[i+1 : i+2])
+				i += 2
+				continue
+			}
+		case DialectMoment:
+			if ch == '[' {
+				if end := strings.IndexByte(format[i:], ']'); end > 0 {
+					literal(format[i+1 : i+end])
+					i += end + 1
+					continue
+				}
+			}
+		case DialectICU:
+			if ch == '\'' {
+				if i+1 < len(format) && format[i+1] == '\'' {
+					literal("'")
+					i += 2
+					continue
+				}
+				end := i + 1
+				var text strings.Builder
+				for end < len(format) {
+					if format[end] == '\'' {
+						if end+1 < len(format) && format[end+1] == '\'' {
+							text.WriteByte('\'')
+							end += 2
+							continue
+						}
+						break
+					}
+					text.WriteByte(format[end])
+					end++
+				}
+				literal(text.String())
+				i = end + 1
+				continue
+			}
+		case DialectGo:
+			// fraction seconds, e.g. ".000", ",000000"
+			if (ch == '.' || ch == ',') && i+1 < len(format) && (format[i+1] == '0' || format[i+1] == '9') {
+				end := i + 1
+				for end < len(format) && format[end] == format[i+1] {
+					end++
+				}
+				if end == len(format) || !isDigit(format[end]) {
+					token := format[i:end]
+					if format[i+1] == '9' || end-i-1 > 9 {
+						return nil, fmt.Errorf("the token '%s' of %s isn't supported", token, dialect)
+					}
+					literal(format[i : i+1])
+					units = append(units, formatUnit{token: token, field: fracField(end - i - 1)})
+					i = end
+					continue
+				}
+			}
+		case DialectStrftime:
+			if ch == '%' {
+				spec, next, ok := readStrftimeSpec(format, i+1)
+				if !ok {
+					return nil, fmt.Errorf("the format ends with an incomplete directive: '%s'", format)
+				}
+				token := format[i : next+1]
+				switch spec.directive {
+				case '%':
+					literal("%")
+				case 'n':
+					literal("\n")
+				case 't':
+					literal("\t")
+				default:
+					key := "%" + spec.flags + string(spec.directive)
+					if spec.colon {
+						key = "%" + spec.flags + ":" + string(spec.directive)
+					}
+					if compose, ok := dialectComposes[dialect][key]; ok {
+						subUnits, err := parseDialectFormat(compose, dialect)
+						if err != nil {
+							return nil, err
+						}
+						for _, unit := range subUnits {
+							if unit.field != "" {
+								unit.token = token
+							}
+							units = append(units, unit)
+						}
+					} else if fields := findDialectToken(dialect, key); fields != nil {
+						for _, field := range fields {
+							units = append(units, formatUnit{token: token, field: field})
+						}
+					} else {
+						return nil, fmt.Errorf("the token '%s' of %s isn't supported", token, dialect)
+					}
+				}
+				i = next + 1
+				continue
+			}
+		}
+		// composed tokens
+		if compose, ok := dialectComposes[dialect][string(ch)]; ok {
+			subUnits, err := parseDialectFormat(compose, dialect)
+			if err != nil {
+				return nil, err
+			}
+			for _, unit := range subUnits {
+				if unit.field != "" {
+					unit.token = string(ch)
+				}
+				units = append(units, unit)
+			}
+			i++
+			continue
+		}
+		// the longest token first
+		matched := false
+		for _, cur := range tokens {
+			if strings.HasPrefix(format[i:], cur.token) {
+				for _, field := range cur.fields {
+					units = append(units, formatUnit{token: cur.token, field: field})
+				}
+				i += len(cur.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			literal(format[i : i+1])
+			i++
+		}
+	}
+	return units, nil
+}
+
+// find the fields of the token
+func findDialectToken(dialect FormatDialect, token string) []string {
+	for _, cur := range dialectTokens[dialect] {
+		if cur.token == token {
+			return cur.fields
+		}
+	}
+	return nil
+}
+
+// escape the literal text for the dialect
+func escapeDialectLiteral(text string, dialect FormatDialect) (string, error) {
+	hasLetter := func(text string) bool {
+		for i := 0; i < len(text); i++ {
+			if isLetter(text[i]) {
+				return true
+			}
+		}
+		return false
+	}
+	switch dialect {
+	case DialectPHP:
+		var result strings.Builder
+		for i := 0; i < len(text); i++ {
+			if isLetter(text[i]) || text[i] == '\\' {
+				result.WriteByte('\\')
+			}
+			result.WriteByte(text[i])
+		}
+		return result.String(), nil
+	case DialectGo:
+		// golang's layout can't escape the characters
+		units, _ := parseDialectFormat(text, DialectGo)
+		for _, unit := range units {
+			if unit.field != "" {
+				return "", fmt.Errorf("the literal '%s' can't be kept in %s layout", text, dialect)
+			}
+		}
+		return text, nil
+	case DialectStrftime:
+		return strings.Replace(text, "%", "%%", -1), nil
+	case DialectMoment:
+		if hasLetter(text) {
+			return "[" + text + "]", nil
+		}
+		return text, nil
+	case DialectICU:
+		if strings.Contains(text, "'") || hasLetter(text) {
+			return "'" + strings.Replace(text, "'", "''", -1) + "'", nil
+		}
+		return text, nil
+	}
+	return text, nil
+}
+
+// ConvertFormat convert the format string from a dialect to another dialect
+// if a token has no equivalent in the target dialect, an error named the token will be returned
+func ConvertFormat(format string, from, to FormatDialect) (string, error) {
+	units, err := parseDialectFormat(format, from)
+	if err != nil {
+		return "", err
+	}
+	tokens, ok := dialectTokens[to]
+	if !ok {
+		return "", fmt.Errorf("unknown format dialect '%s'", to)
+	}
+	var (
+		result  strings.Builder
+		literal strings.Builder
+	)
+	flush := func() error {
+		if literal.Len() > 0 {
+			text, err := escapeDialectLiteral(literal.String(), to)
+			if err != nil {
+				return err
+			}
+			result.WriteString(text)
+			literal.Reset()
+		}
+		return nil
+	}
+	for i := 0; i < len(units); {
+		unit := units[i]
+		if unit.field == "" {
+			literal.WriteString(unit.literal)
+			i++
+			continue
+		}
+		// golang's fraction seconds must after a '.' or ','
+		if to == DialectGo && strings.HasPrefix(unit.field, fieldFracPrefix) {
+			text := literal.String()
+			if text == "" || !strings.ContainsAny(text[len(text)-1:], ".,") {
+				return "", fmt.Errorf("the token '%s' of %s must be after '.' or ',' in %s layout", unit.token, from, to)
+			}
+			if err := flush(); err != nil {
+				return "", err
+			}
+			result.WriteString(strings.Repeat("0", int(unit.field[len(fieldFracPrefix)]-'0')))
+			i++
+			continue
+		}
+		if err := flush(); err != nil {
+			return "", err
+		}
+		// find the token matched most fields
+		var matched *formatToken
+		for index, cur := range tokens {
+			count := len(cur.fields)
+			if i+count > len(units) || (matched != nil && count <= len(matched.fields)) {
+				continue
+			}
+			same := true
+			for k, field := range cur.fields {
+				if units[i+k].field != field {
+					same = false
+					break
+				}
+			}
+			if same {
+				matched = &tokens[index]
+			}
+		}
+		if matched == nil {
+			return "", fmt.Errorf("the token '%s' of %s isn't supported in %s, it has no equivalent", unit.token, from, to)
+		}
+		result.WriteString(matched.token)
+		i += len(matched.fields)
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
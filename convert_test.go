@@ -0,0 +1,131 @@
+package dateutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertFormat(t *testing.T) {
+	formats := map[FormatDialect]string{
+		DialectPHP:      "Y-m-d H:i:s",
+		DialectGo:       "2006-01-02 15:04:05",
+		DialectStrftime: "%Y-%m-%d %H:%M:%S",
+		DialectMoment:   "YYYY-MM-DD HH:mm:ss",
+		DialectICU:      "yyyy-MM-dd HH:mm:ss",
+	}
+	// convert between all the dialects
+	for from, format := range formats {
+		for to, expect := range formats {
+			if result, err := ConvertFormat(format, from, to); err == nil {
+				assert.Equal(t, result, expect)
+			} else {
+				assert.Fail(t, "ConvertFormat from "+from.String()+" to "+to.String()+" fail")
+			}
+		}
+	}
+	// names
+	if result, err := ConvertFormat("D, d M Y g:i A", DialectPHP, DialectICU); err == nil {
+		assert.Equal(t, result, "EEE, dd MMM yyyy h:mm a")
+	} else {
+		assert.Fail(t, "ConvertFormat names fail")
+	}
+	if result, err := ConvertFormat("l, F j, Y", DialectPHP, DialectStrftime); err == nil {
+		assert.Equal(t, result, "%A, %B %-d, %Y")
+	} else {
+		assert.Fail(t, "ConvertFormat full names fail")
+	}
+	// ordinal day
+	if result, err := ConvertFormat("jS F Y", DialectPHP, DialectMoment); err == nil {
+		assert.Equal(t, result, "Do MMMM YYYY")
+	} else {
+		assert.Fail(t, "ConvertFormat ordinal fail")
+	}
+	if result, err := ConvertFormat("Do MMM", DialectMoment, DialectPHP); err == nil {
+		assert.Equal(t, result, "jS M")
+	} else {
+		assert.Fail(t, "ConvertFormat moment ordinal fail")
+	}
+	// literals, fraction seconds and timezones
+	if result, err := ConvertFormat("Y-m-d\\TH:i:s.vP", DialectPHP, DialectGo); err == nil {
+		assert.Equal(t, result, "2006-01-02T15:04:05.000-07:00")
+	} else {
+		assert.Fail(t, "ConvertFormat to golang fail")
+	}
+	if result, err := ConvertFormat("Y-m-d\\TH:i:s.vP", DialectPHP, DialectMoment); err == nil {
+		assert.Equal(t, result, "YYYY-MM-DD[T]HH:mm:ss.SSSZ")
+	} else {
+		assert.Fail(t, "ConvertFormat to moment fail")
+	}
+	if result, err := ConvertFormat("2006-01-02T15:04:05.000000Z07:00", DialectGo, DialectPHP); err == nil {
+		assert.Equal(t, result, "Y-m-d\\TH:i:s.up")
+	} else {
+		assert.Fail(t, "ConvertFormat from golang fail")
+	}
+	if result, err := ConvertFormat("h 'o''clock' a", DialectICU, DialectPHP); err == nil {
+		assert.Equal(t, result, "g \\o'\\c\\l\\o\\c\\k A")
+	} else {
+		assert.Fail(t, "ConvertFormat icu quotes fail")
+	}
+	if result, err := ConvertFormat("100% Y", DialectPHP, DialectStrftime); err == nil {
+		assert.Equal(t, result, "100%% %Y")
+	} else {
+		assert.Fail(t, "ConvertFormat strftime percent fail")
+	}
+	// composed tokens
+	if result, err := ConvertFormat("%F %T", DialectStrftime, DialectPHP); err == nil {
+		assert.Equal(t, result, "Y-m-d H:i:s")
+	} else {
+		assert.Fail(t, "ConvertFormat strftime composed fail")
+	}
+	if result, err := ConvertFormat("c", DialectPHP, DialectICU); err == nil {
+		assert.Equal(t, result, "yyyy-MM-dd'T'HH:mm:ssxxx")
+	} else {
+		assert.Fail(t, "ConvertFormat php composed fail")
+	}
+	if result, err := ConvertFormat("%e %H", DialectStrftime, DialectGo); err == nil {
+		assert.Equal(t, result, "_2 15")
+	} else {
+		assert.Fail(t, "ConvertFormat space padding fail")
+	}
+	// no equivalent tokens
+	if _, err := ConvertFormat("G:i", DialectPHP, DialectGo); err == nil {
+		assert.Fail(t, "ConvertFormat 'G' to golang ok")
+	} else {
+		assert.True(t, strings.Contains(err.Error(), "'G'"))
+	}
+	if _, err := ConvertFormat("%e %k", DialectStrftime, DialectGo); err == nil {
+		assert.Fail(t, "ConvertFormat '%k' to golang ok")
+	} else {
+		assert.True(t, strings.Contains(err.Error(), "'%k'"))
+	}
+	if _, err := ConvertFormat("s.v", DialectPHP, DialectStrftime); err == nil {
+		assert.Fail(t, "ConvertFormat 'v' to strftime ok")
+	} else {
+		assert.True(t, strings.Contains(err.Error(), "'v'"))
+	}
+	if _, err := ConvertFormat("sv", DialectPHP, DialectGo); err == nil {
+		assert.Fail(t, "ConvertFormat fraction without dot to golang ok")
+	}
+	if _, err := ConvertFormat("\\M\\o\\n Y", DialectPHP, DialectGo); err == nil {
+		assert.Fail(t, "ConvertFormat layout literal to golang ok")
+	}
+	// the offsets of golang's layout without the equivalents
+	for _, token := range []string{"-07", "Z07", "-070000", "Z070000", "-07:00:00", "Z07:00:00"} {
+		layout := "2006-01-02T15:04:05" + token
+		if _, err := ConvertFormat(layout, DialectGo, DialectPHP); err == nil {
+			assert.Fail(t, "ConvertFormat '"+token+"' to php ok")
+		} else {
+			assert.True(t, strings.Contains(err.Error(), "'"+token+"' of golang isn't supported"), err.Error())
+		}
+		if result, err := ConvertFormat(layout, DialectGo, DialectGo); err == nil {
+			assert.Equal(t, result, layout)
+		} else {
+			assert.Fail(t, "ConvertFormat '"+token+"' to golang fail")
+		}
+	}
+	if _, err := ConvertFormat("%Q", DialectStrftime, DialectPHP); err == nil {
+		assert.Fail(t, "ConvertFormat unknown directive ok")
+	}
+}
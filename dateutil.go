@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -57,9 +58,44 @@ var (
 		// weekday
 		"l": "(" + strings.Join(weekdayFullNames, "|") + ")",
 		"D": "(" + strings.Join(weekdayShortNames, "|") + ")",
+		// quarter/half year
+		"Q":  "([1-4])",
+		"HY": "([1-2])",
+		// japanese era and the year of the era
+		"era": japaneseEraExp(),
+		"ey":  "([0-9]{1,2}|元)",
+		// the minguo calendar
+		"roc": "(民國|民国)",
+		// the hijri months and the suffix of the hijri years
+		"hm": hijriMonthExp(),
+		"ah": "(AH|A\\.H\\.|هـ)",
 	}
 	dateRules = []string{
 		// keep the orders, make sure match as much as more characters
+		// the CJK dates
+		"^${YY}[年년][ \\t]*${mm}[月월][ \\t]*${dd}[日일号號]", // "2021年9月5日", "2021년 9월 5일", "2021年09月05号"
+		"^${YY}[年년][ \\t]*${mm}[月월]",                   // "2021年9月", "2021년 9월"
+		"^${mm}[月월][ \\t]*${dd}[日일号號]",                 // "9月5日", "9월 5일"
+		"^${YY}[年년]", // "2021年", "2021년"
+		// the japanese eras
+		"(?i)^${era}[ \\t]*${ey}年[ \\t]*${mm}月[ \\t]*${dd}日", // "令和3年9月5日", "平成元年1月8日"
+		"(?i)^${era}[ \\t]*${ey}[./-]${mm}[./-]${dd}",        // "R3.9.5", "H31/04/30", "Reiwa 3-9-5"
+		"(?i)^${era}[ \\t]*${ey}年[ \\t]*${mm}月",              // "令和3年9月"
+		"(?i)^${era}[ \\t]*${ey}年",                           // "令和3年"
+		// the minguo years
+		"^${roc}[ \\t]*${y}年[ \\t]*${mm}月[ \\t]*${dd}日", // "民國110年9月5日"
+		"^${roc}[ \\t]*${y}年[ \\t]*${mm}月",              // "民國110年9月"
+		"^${roc}[ \\t]*${y}年",                           // "民国110年"
+		// the hijri months
+		"(?i)^${dd}[ \\t.-]*${hm}[ \\t,.-]*${y}(?:[ \\t]*${ah})?", // "27 Muharram 1443", "27 محرم 1443 هـ"
+		"(?i)^${hm}[ \\t.-]*${dd}[ \\t,]+${y}(?:[ \\t]*${ah})?",   // "Muharram 27, 1443"
+		"(?i)^${hm}[ \\t.-]*${y}(?:[ \\t]*${ah})?",                // "Ramadan 1443", "Ramadan 1443 AH"
+		"(?i)^${dd}[ \\t.-]*${hm}",                                // "27 Muharram"
+
+		"(?i)^${YY}[ \\t-]?Q${Q}",                    // "2021-Q3", "2021Q3", "2021 q3"
+		"(?i)^Q${Q}[ \\t/-]*${YY}",                   // "Q3 2021", "Q3-2021", "Q3/2021"
+		"(?i)^${YY}[ \\t-]?H${HY}",                   // "2021H1", "2021-H2"
+		"(?i)^H${HY}[ \\t/-]*${YY}",                  // "H1 2021", "H2/2021"
 		"^[+-]?${YY}-${MM}-${DD}",                    // "-0002-07-26", "+1978-04-17", "1814-05-17"
 		"^${dd}[.\\t-]${mm}[.-]${YY}",                // "30-6-2008", "22.12.1978"
 		"^${YY}-${mm}-${dd}",                         // "2008-6-30", "1978-12-22"
@@ -83,11 +119,17 @@ var (
 		"^${YY}",                                     // "1978", "2008"
 		"(?i)^${m}",                                  // "March", "jun", "DEC"
 	}
+	// the CJK dates with the short years, only used with the calendar systems
+	calendarDateRules = []string{
+		"^${y}[年년][ \\t]*${mm}[月월][ \\t]*${dd}[日일号號]", // "110年9月5日", "2564年9月5日"
+		"^${y}[年년][ \\t]*${mm}[月월]",                   // "110年9月"
+		"^${y}[年년]",                                   // "110年"
+	}
 	timeFormats = FormatList{
 		"frac":               "([0-9]{1,9})",
 		"hh":                 "(1[0-2]|0?[0-9])",
 		"HH":                 "(1[0-9]|2[0-4]|0?[0-9])",
-		"meridian":           "([AaPp]\\.?[Mm](?:\\.?|\\b|$))",
+		"meridian":           "([AaPp]\\.?[Mm](?:\\.?|\\b|$)|上午|下午|午前|午後|오전|오후)",
 		"MN":                 "([1-5][0-9]|0?[0-9])",
 		"MNA":                "([0-5][0-9])",
 		"II":                 "([1-5][0-9]|0?[0-9])",
@@ -98,17 +140,24 @@ var (
 		"tzcorrection_plain": "([+-](?:1[0-2]|0?[0-9]):?(?:[0-5][0-9])?)",
 	}
 	timeRules = []string{
-		"(?i)^${hh}:${MN}:${II}[.:]${frac}${meridian}$",                       // "4:08:39:12313am"
-		"(?i)^${hh}[.:]${MN}[.:]${II}[ \\t]?${meridian}$",                     // "4:08:37 am", "7:19:19P.M."
-		"(?i)^t?${HH}[.:]?${MNA}[.:]?${IIA}[ \\t]?(?:${tzcorrection}|${tz})$", // "040837CEST", "T191919-0700"
-		"(?i)^${hh}[.:]${MN}[ \\t]?${meridian}$",                              // "4:08 am", "7:19P.M."
-		"(?i)^t?${HH}[.:]${MN}[.:]${II}\\.${frac}$",                           // "04.08.37.81412", "19:19:19.532453"
-		"(?i)^t?${HH}[.:]${MN}[.:]${II}$",                                     // "04.08.37", "t19:19:19"
-		"(?i)^t?${HH}[.:]${MN}$",                                              // "04:08", "19.19", "T23:43"
-		"(?i)^${hh}[ \\t]?${meridian}$",                                       // "4 am", "5PM"
-		"(?i)^t?${HH}${MNA}${IIA}$",                                           // "040837", "T191919"
-		"(?i)^t?${HH}${MNA}$",                                                 // "0408", "t1919", "T2343"
-		"(?i)^(?:${tzcorrection}|${tz})$",                                     // "CEST", "Europe/Amsterdam", "+0430", "GMT-06:00"
+		"(?i)^${hh}:${MN}:${II}[.:]${frac}${meridian}$",                              // "4:08:39:12313am"
+		"(?i)^${hh}[.:]${MN}[.:]${II}[ \\t]?${meridian}$",                            // "4:08:37 am", "7:19:19P.M."
+		"(?i)^t?${HH}[.:]?${MNA}[.:]?${IIA}[ \\t]?(?:${tzcorrection}|${tz})$",        // "040837CEST", "T191919-0700"
+		"(?i)^${hh}[.:]${MN}[ \\t]?${meridian}$",                                     // "4:08 am", "7:19P.M."
+		"(?i)^t?${HH}[.:]${MN}[.:]${II}\\.${frac}$",                                  // "04.08.37.81412", "19:19:19.532453"
+		"(?i)^t?${HH}[.:]${MN}[.:]${II}$",                                            // "04.08.37", "t19:19:19"
+		"(?i)^t?${HH}[.:]${MN}$",                                                     // "04:08", "19.19", "T23:43"
+		"(?i)^${hh}[ \\t]?${meridian}$",                                              // "4 am", "5PM"
+		"(?i)^t?${HH}${MNA}${IIA}$",                                                  // "040837", "T191919"
+		"(?i)^t?${HH}${MNA}$",                                                        // "0408", "t1919", "T2343"
+		"(?i)^t?${HH}[.:]${MN}[.:]${II}[.,]${frac}[ \\t]?(?:${tzcorrection}|${tz})$", // "19:19:19.532453Z", "T18:07:06,5+08:00"
+		"(?i)^t?${HH}[.:]${MNA}[ \\t]?(?:${tzcorrection}|${tz})$",                    // "T23:43Z", "19:19 +0430"
+		"(?i)^(?:${tzcorrection}|${tz})$",                                            // "CEST", "Europe/Amsterdam", "+0430", "GMT-06:00"
+
+		// the CJK times
+		"^${meridian}[ \\t]*${hh}[时時시点點][ \\t]*(?:${MN}[分분][ \\t]*(?:${II}[秒초])?)?$", // "下午6时07分", "午後6時", "오후 6시 7분"
+		"^${HH}[时時시点點][ \\t]*(?:${MN}[分분][ \\t]*(?:${II}[秒초])?)?$",                   // "18时07分06秒", "18時", "18시 7분"
+		"^${meridian}[ \\t]*${hh}:${MN}(?::${II})?$",                                 // "下午6:07", "午前6:07:06"
 	}
 	// will fill next
 	rfcFormats = FormatList{}
@@ -131,10 +180,12 @@ var (
 		"^(?i)${D},[ \\t]+${DD}[ \\t]+${M}[ \\t]+${YY}[ \\t]+${HH}:${MN}:${II}[ \\t]+(?:${tz_plain}|${tzcorrection_plain})",
 	}
 	allFormats = map[string]*FormatList{
-		"date": &dateFormats,
-		"time": &timeFormats,
+		"date":     &dateFormats,
+		"time":     &timeFormats,
+		"calendar": &dateFormats,
 	}
 	allPatternInfo = map[string]*PatternInfo{}
+	patternMutex   sync.Mutex
 )
 
 // get weekday number
@@ -222,11 +273,12 @@ func StrToTime(target interface{}) (int64, error) {
 }
 
 // DateTime func
-func DateTime(target interface{}) (time.Time, error) {
+func DateTime(target interface{}, opts ...Option) (time.Time, error) {
+	o := makeOptions(opts)
 	var timestamp int64
 	switch t := target.(type) {
 	case time.Time:
-		return t.In(time.Local), nil
+		return t.In(o.location), nil
 	case int64:
 		timestamp = t
 	case int:
@@ -236,64 +288,132 @@ func DateTime(target interface{}) (time.Time, error) {
 	case float64:
 		timestamp = int64(t)
 	case string:
-		var (
-			lasts     FormatResult
-			isRFCTime bool
-		)
-		t = strings.TrimSpace(t)
-		// match golang and rfc format first
-		if result, loc, ok := matchRFCFormat(t); ok {
-			if len(t) == loc[1] {
-				lasts = result
-				isRFCTime = true
+		// the same as the time of 'Parse'
+		result, err := Parse(t, opts...)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return result.Time, nil
+	default:
+		// other conditions
+		return time.Time{}, fmt.Errorf("can't parse the datetime: %#v", target)
+	}
+	return time.Unix(timestamp, 0).In(o.location), nil
+}
+
+// check if the meridian is after noon, e.g. "pm", "P.M.", "下午", "午後", "오후"
+func isPostMeridian(meridian string) bool {
+	switch meridian {
+	case "下午", "午後", "오후":
+		return true
+	}
+	return meridian != "" && (meridian[0] == 'P' || meridian[0] == 'p')
+}
+
+// change the full-width characters to the ascii ones, e.g. "２０２１／９／５" is "2021/9/5"
+// the ideographic space is changed to the space, the arabic-indic digits are changed to the ascii digits
+func normalizeWidth(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u3000':
+			return ' '
+		case r >= '\uFF01' && r <= '\uFF5E':
+			return r - 0xFEE0
+		case r >= '\u0660' && r <= '\u0669':
+			return '0' + r - '\u0660'
+		case r >= '\u06F0' && r <= '\u06F9':
+			return '0' + r - '\u06F0'
+		}
+		return r
+	}, value)
+}
+
+// match the datetime string, return the format result and the matched patterns
+// the names of the months and weekdays in the locale are also matched
+func matchDateTime(t string, locale *Locale) (FormatResult, []*Pattern, error) {
+	t = locale.removeFillers(normalizeWidth(t))
+	var (
+		lasts    FormatResult
+		patterns []*Pattern
+	)
+	// match golang and rfc format first
+	if pattern, result, loc := matchRFCFormat(t, locale); pattern != nil && len(t) == loc[1] {
+		return result, []*Pattern{pattern}, nil
+	}
+	// match time first
+	if pattern, result, _ := matchTimeFormat(t); pattern != nil {
+		// if timezone, but match a en month
+		if timeResult, ok := transTimezoneResult(result); ok {
+			lasts = timeResult
+		} else {
+			lasts = result
+		}
+		patterns = append(patterns, pattern)
+	} else {
+		// not a time format, so maybe a date or a datetime
+		// match the date format first
+		if pattern, result, loc := matchDateFormat(t, locale); pattern != nil {
+			// set lasts
+			lasts = result
+			patterns = append(patterns, pattern)
+			// get the left characters after date string
+			if timePattern, ok := matchTimeSuffix(t[loc[1]:], lasts); !ok {
+				return nil, nil, fmt.Errorf("wrong time format:'%s'", t)
+			} else if timePattern != nil {
+				patterns = append(patterns, timePattern)
 			}
+		} else {
+			return nil, nil, fmt.Errorf("wrong date or datetime:'%s'", t)
 		}
-		// not rfc time
-		if !isRFCTime {
-			// match time first
-			if result, _, ok := matchTimeFormat(t); ok {
-				// if timezone, but match a en month
-				if timeResult, ok := transTimezoneResult(result); ok {
-					lasts = timeResult
-				} else {
-					lasts = result
-				}
+	}
+	if lasts != nil {
+		return lasts, patterns, nil
+	}
+	return nil, nil, fmt.Errorf("wrong datetime string:%s", t)
+}
+
+// match the time after the date and merge the fields into the date result,
+// the pattern is nil if no more characters, return false if the time is wrong
+func matchTimeSuffix(suffix string, lasts FormatResult) (*Pattern, bool) {
+	timeFormat := strings.TrimSpace(suffix)
+	if timeFormat == "" {
+		return nil, true
+	}
+	timePattern, result, _ := matchTimeFormat(timeFormat)
+	if timePattern == nil {
+		return nil, false
+	}
+	for key, value := range result {
+		lasts[key] = value
+	}
+	return timePattern, true
+}
+
+// get the month of the english name, the short name or the roman number, 0 if not a month name
+// the roman numbers are found by the letters rather than the index, the short names have both 'sep' and 'sept'
+func monthNameNum(name string) int {
+	name = strings.ToLower(name)
+	for _, cur := range allMonthExp {
+		if cur != name {
+			continue
+		}
+		if strings.Trim(name, "ivx") != "" {
+			return int(getMonthNum(name))
+		}
+		// roman
+		month, prev := 0, 0
+		for idx, num := range []rune(name) {
+			value := romanNumHash[num]
+			if idx > 0 && value > prev {
+				month = value - prev
 			} else {
-				// not a time format, so maybe a date or a datetime
-				// match the date format first
-				if result, loc, ok := matchDateFormat(t); ok {
-					// set lasts
-					lasts = result
-					// set next index
-					nextIndex := loc[1]
-					// get the left characters after date string
-					suffix := t[nextIndex:]
-					// no more characters
-					timeFormat := strings.TrimSpace(suffix)
-					// special date
-					if timeFormat != "" {
-						if result, _, ok := matchTimeFormat(timeFormat); ok {
-							for key, value := range result {
-								lasts[key] = value
-							}
-						} else {
-							return time.Time{}, fmt.Errorf("wrong time format:'%s'", t)
-						}
-					}
-				} else {
-					return time.Time{}, fmt.Errorf("wrong date or datetime:'%s'", t)
-				}
+				month += value
 			}
+			prev = value
 		}
-		if lasts != nil {
-			return makeFormatDateTime(lasts)
-		}
-		return time.Time{}, fmt.Errorf("wrong datetime string:%s", t)
-	default:
-		// other conditions
-		return time.Time{}, fmt.Errorf("can't parse the datetime: %#v", target)
+		return month
 	}
-	return time.Unix(timestamp, 0).In(time.Local), nil
+	return 0
 }
 
 // get any of the argument fields in the target format result
@@ -308,11 +428,11 @@ func noEmptyField(target FormatResult, args ...string) string {
 }
 
 // translate result information to a time struct
-func makeFormatDateTime(result FormatResult) (time.Time, error) {
+func makeFormatDateTime(result FormatResult, o *options) (time.Time, error) {
 	// tz, tzcorrection
 	var lastTime time.Time
-	// set default timezone as 'Local'
-	timezone := "Local"
+	// set default timezone as the location of the options
+	timezone := ""
 	// get matched tz
 	hasTimezone := false
 	tz := noEmptyField(result, "tz", "tz_plain")
@@ -332,9 +452,15 @@ func makeFormatDateTime(result FormatResult) (time.Time, error) {
 		needCorrection = true
 	}
 	// load location
-	location, err := time.LoadLocation(timezone)
-	if err != nil {
-		return time.Time{}, err
+	location := o.location
+	if strings.EqualFold(timezone, "Z") {
+		// the zulu time of ISO 8601
+		location = time.UTC
+	} else if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return time.Time{}, err
+		}
 	}
 	// plain timezone, set hour/minute/second/nanoseconds to now time
 	if (hasTimezone || needCorrection) && isResultTimezone(result) {
@@ -344,12 +470,27 @@ func makeFormatDateTime(result FormatResult) (time.Time, error) {
 	} else {
 		// current time
 		now := time.Now()
+		// the calendar of the years, months and days, the minguo years are marked by the prefix,
+		// the hijri month names use the umm al-qura calendar if the calendar option is not a hijri calendar
+		calendar := o.calendarSystem
+		if result["roc"] != "" {
+			calendar = MinguoCalendar
+		} else if _, ok := calendar.(HijriCalendar); !ok && result["hm"] != "" {
+			calendar = UmmAlQuraCalendar
+		}
+		nowYear, nowMonth, nowDay := now.Year(), int(now.Month()), now.Day()
+		if calendar != nil {
+			nowYear, nowMonth, nowDay = calendar.FromGregorian(now)
+		}
 		// get full year of current
-		year := now.Year()
+		year := nowYear
 		strYear := strconv.Itoa(year)
 		rnYear := []rune(strYear)
 		curYear := noEmptyField(result, "YY", "yy", "y")
-		if curYear != "" {
+		if curYear != "" && calendar != nil {
+			// the years of the calendar are not expanded
+			year, _ = strconv.Atoi(curYear)
+		} else if curYear != "" {
 			rns := []rune(curYear)
 			total := len(curYear)
 			switch total {
@@ -371,34 +512,17 @@ func makeFormatDateTime(result FormatResult) (time.Time, error) {
 		var month int
 		if curMonth != "" {
 			month, _ = strconv.Atoi(curMonth)
+		} else if hijriMonth := result["hm"]; hijriMonth != "" {
+			month = hijriMonthNum(hijriMonth)
 		} else {
 			curMonth = noEmptyField(result, "M", "m")
 			if curMonth != "" {
-				curMonth = strings.ToLower(curMonth)
-				for index, name := range allMonthExp {
-					if name == curMonth {
-						if index >= 24 {
-							// roman
-							month = 0
-							prev := 0
-							rns := []rune(name)
-							for idx, num := range rns {
-								value := romanNumHash[num]
-								if idx > 0 && value > prev {
-									month = value - prev
-								} else {
-									month += value
-								}
-								prev = value
-							}
-						} else {
-							month = index%12 + 1
-						}
-						break
-					}
+				// the month name of the locale, then the english or roman names
+				if month = o.locale.monthNum(curMonth); month == 0 {
+					month = monthNameNum(curMonth)
 				}
 			} else {
-				month = int(now.Month())
+				month = nowMonth
 			}
 		}
 		// day
@@ -407,15 +531,31 @@ func makeFormatDateTime(result FormatResult) (time.Time, error) {
 		if curDay != "" {
 			day, _ = strconv.Atoi(curDay)
 		} else {
-			day = now.Day()
+			day = nowDay
+		}
+		// the first month of the quarter or half year
+		if quarter := noEmptyField(result, "Q"); quarter != "" {
+			num, _ := strconv.Atoi(quarter)
+			month, day = (num-1)*3+1, 1
+		} else if half := noEmptyField(result, "HY"); half != "" {
+			num, _ := strconv.Atoi(half)
+			month, day = (num-1)*6+1, 1
+		}
+		// the gregorian date of the calendar
+		if calendar != nil {
+			year, month, day = calendar.ToGregorian(year, month, day)
 		}
 		// hour
 		var hour int
 		curHour := noEmptyField(result, "HH", "hh")
 		if curHour != "" {
 			hour, _ = strconv.Atoi(curHour)
-			if meridian, ok := result["meridian"]; ok && (meridian[0] == 'P' || meridian[0] == 'p') {
-				hour += 12
+			// the same as 'ParseFormat', "12am" is the midnight and "12pm" is the noon
+			if meridian := result["meridian"]; meridian != "" {
+				hour %= 12
+				if isPostMeridian(meridian) {
+					hour += 12
+				}
 			}
 		} else {
 			hour = 0
@@ -453,19 +593,11 @@ func makeFormatDateTime(result FormatResult) (time.Time, error) {
 		// weekday
 		weekday := noEmptyField(result, "l", "D")
 		if weekday != "" {
-			// if the weekday is not current day
-			// fix the day to that weekday
-			curWeekday := int(lastTime.Weekday())
-			relWeekday := getWeekdayNum(weekday)
-			forwardDays := relWeekday - curWeekday
-			if forwardDays != 0 {
-				// make sure the days is increased
-				if forwardDays < 0 {
-					forwardDays += 7
-				}
-				// add date
-				lastTime = lastTime.AddDate(0, 0, forwardDays)
+			num := o.locale.weekdayNum(weekday)
+			if num < 0 {
+				num = getWeekdayNum(weekday)
 			}
+			lastTime = forwardToWeekday(lastTime, num)
 		}
 	}
 	// fix time to GMT/UTC+0000 time
@@ -487,18 +619,56 @@ func makeFormatDateTime(result FormatResult) (time.Time, error) {
 		correct := (time.Duration(addHour)*time.Hour + time.Duration(addMinute)*time.Minute) * time.Duration(multi)
 		lastTime = lastTime.Add(correct)
 	}
-	// Change time to local time
-	if timezone != "Local" {
-		lastTime = lastTime.In(time.Local)
+	// Change time to the location of the options
+	if location != o.location {
+		lastTime = lastTime.In(o.location)
 	}
 	return lastTime, nil
 }
 
+// if the weekday is not the day of the time
+// fix the day forward to that weekday
+func forwardToWeekday(t time.Time, weekday int) time.Time {
+	forwardDays := weekday - int(t.Weekday())
+	if forwardDays != 0 {
+		// make sure the days is increased
+		if forwardDays < 0 {
+			forwardDays += 7
+		}
+		// add date
+		t = t.AddDate(0, 0, forwardDays)
+	}
+	return t
+}
+
+// check if is leap year
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// get how many days of the month
+func daysInMonth(year int, month time.Month) int {
+	nums := [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	// if is leap Year, the second month has 29 days.
+	if month == time.February && isLeapYear(year) {
+		return 29
+	}
+	return nums[month-1]
+}
+
+// get the calendar days from the date of 'a' to the date of 'b'
+func daysBetween(a, b time.Time) int {
+	aYear, aMonth, aDay := a.Date()
+	bYear, bMonth, bDay := b.Date()
+	// use UTC to avoid the daylight saving time
+	days := time.Date(bYear, bMonth, bDay, 0, 0, 0, 0, time.UTC).Sub(time.Date(aYear, aMonth, aDay, 0, 0, 0, 0, time.UTC)).Hours() / 24
+	return int(days)
+}
+
 // make patterns
 // save the patterns into global variable 'allPatternInfo'
 func makePatterns(t string, rules ...string) (*PatternInfo, error) {
 	var (
-		ptns       = []*Pattern{}
 		ok         bool
 		formatList *FormatList
 	)
@@ -518,213 +688,260 @@ func makePatterns(t string, rules ...string) (*PatternInfo, error) {
 		ok = true
 	}
 	if ok {
-		regRule, _ := regexp.Compile(`\$\{[A-Za-z_]+}`)
-		for _, rule := range rules {
-			pattern := new(Pattern)
-			pattern.Type = t
-			pattern.Original = rule
-			keys := []string{}
-			context := regRule.ReplaceAllStringFunc(rule, func(all string) string {
-				rns := []rune(all)
-				key := string(rns[2 : len(rns)-1])
-				if seg, ok := (*formatList)[key]; ok {
-					keys = append(keys, key)
-					return seg
-				}
-				return all
-			})
-			curRule := regexp.MustCompile(context)
-			pattern.Rule = curRule
-			pattern.Keys = keys
-			ptns = append(ptns, pattern)
-		}
 		info := new(PatternInfo)
-		info.Patterns = ptns
+		info.Patterns = compilePatterns(t, formatList, rules)
 		allPatternInfo[t] = info
 		return info, nil
 	}
 	return nil, fmt.Errorf("the format type '%s' doesn't exist", t)
 }
 
+// compile the rules by replacing the placeholders with the formats
+func compilePatterns(t string, formatList *FormatList, rules []string) []*Pattern {
+	ptns := []*Pattern{}
+	regRule, _ := regexp.Compile(`\$\{[A-Za-z_]+}`)
+	for _, rule := range rules {
+		pattern := new(Pattern)
+		pattern.Type = t
+		pattern.Original = rule
+		keys := []string{}
+		context := regRule.ReplaceAllStringFunc(rule, func(all string) string {
+			rns := []rune(all)
+			key := string(rns[2 : len(rns)-1])
+			if seg, ok := (*formatList)[key]; ok {
+				keys = append(keys, key)
+				return seg
+			}
+			return all
+		})
+		curRule := regexp.MustCompile(context)
+		pattern.Rule = curRule
+		pattern.Keys = keys
+		ptns = append(ptns, pattern)
+	}
+	return ptns
+}
+
 // factory match format
-func factoryMatchFormat(key string, rules []string, target string) (FormatResult, []int, bool) {
-	var patterns []*Pattern
-	if info, ok := allPatternInfo[key]; ok {
-		// get patterns from cache
-		patterns = info.Patterns
-	} else {
-		// save patterns to cache
-		info, _ := makePatterns(key, rules...)
-		patterns = info.Patterns
-	}
-	for _, pattern := range patterns {
+// the pattern is nil if no rule matched
+func factoryMatchFormat(key string, rules []string, target string, locale *Locale) (*Pattern, FormatResult, []int) {
+	for _, pattern := range getPatterns(key, rules, locale) {
 		if result, loc, ok := pattern.Match(target); ok {
-			return result, loc, true
+			return pattern, result, loc
 		}
 	}
-	return nil, nil, false
+	return nil, nil, nil
+}
+
+// get the patterns of the rules from the cache
+// the month and weekday names of the locale are included if the locale is not nil
+func getPatterns(key string, rules []string, locale *Locale) []*Pattern {
+	patternMutex.Lock()
+	defer patternMutex.Unlock()
+	info, ok := allPatternInfo[key]
+	if !ok {
+		// save patterns to cache
+		info, _ = makePatterns(key, rules...)
+	}
+	if locale == nil {
+		return info.Patterns
+	}
+	localeKey := key + "@" + strings.ToLower(locale.Name)
+	if localeInfo, ok := allPatternInfo[localeKey]; ok {
+		return localeInfo.Patterns
+	}
+	localeInfo := new(PatternInfo)
+	localeInfo.Patterns = compilePatterns(key, locale.formats(allFormats[key]), rules)
+	allPatternInfo[localeKey] = localeInfo
+	return localeInfo.Patterns
 }
 
 // golang/RFC formats
-func matchRFCFormat(target string) (FormatResult, []int, bool) {
-	return factoryMatchFormat(RFCSYMBOL, rfcRules, target)
+func matchRFCFormat(target string, locale *Locale) (*Pattern, FormatResult, []int) {
+	return factoryMatchFormat(RFCSYMBOL, rfcRules, target, locale)
 }
 
 // date fomrats
-func matchDateFormat(target string) (FormatResult, []int, bool) {
-	return factoryMatchFormat("date", dateRules, target)
+func matchDateFormat(target string, locale *Locale) (*Pattern, FormatResult, []int) {
+	pattern, result, loc := factoryMatchFormat("date", dateRules, target, locale)
+	// the japanese era year to the gregorian year
+	if pattern != nil && !resolveJapaneseEra(result) {
+		return nil, nil, nil
+	}
+	return pattern, result, loc
 }
 
 // time formats
-func matchTimeFormat(target string) (FormatResult, []int, bool) {
-	return factoryMatchFormat("time", timeRules, target)
+func matchTimeFormat(target string) (*Pattern, FormatResult, []int) {
+	return factoryMatchFormat("time", timeRules, target, nil)
 }
 
-// DateFormat func
-func DateFormat(target interface{}, format string) (string, error) {
-	// the golang layout, use the format of the golang born time
-	// this is used for strings.NewReplacer
-	// so they are appeared pairs with a string array
-	layouts := []string{
+var (
+	// the golang layouts of the format characters
+	formatLayouts = map[byte]string{
 		// year
-		"Y", "2006",
-		"y", "06",
+		'Y': "2006",
+		'y': "06",
 		// month
-		"m", "01",
-		"n", "1",
+		'm': "01",
+		'n': "1",
+		'F': "January",
+		'M': "Jan",
 		// date
-		"d", "02",
-		"j", "2",
+		'd': "02",
+		'j': "2",
+		// week
+		'D': "Mon",
+		'l': "Monday",
+		// am, pm
+		'a': "pm",
+		'A': "PM",
 		// hours
-		"h", "03",
-		"g", "3",
-		"G", "15",
+		'h': "03",
+		'g': "3",
+		'G': "15",
 		// minutes
-		"i", "04",
+		'i': "04",
 		// seconds
-		"s", "05",
+		's': "05",
+	}
+	// the format characters can't use the golang layouts
+	formatFns = map[byte]func(t time.Time) string{
+		// weekday, from monday to sunday
+		// monday return 1, sunday return 7
+		// it's the same as golang's weekday of time.Time.
+		'N': func(t time.Time) string {
+			weekday := t.Weekday()
+			return fmt.Sprintf("%d", int(weekday))
+		},
+		// weekday, from sunday to saturday
+		// sunday return 0, saturday return 6
+		'w': func(t time.Time) string {
+			weekday := t.Weekday()
+			dayNum := (int(weekday) + 1) % 7
+			return fmt.Sprintf("%d", dayNum)
+		},
+		// the day of the year, from 0 to 365
+		// the golang's yearday is from 1 to 366
+		// so here need reduce by one day
+		'z': func(t time.Time) string {
+			yearday := t.YearDay()
+			return fmt.Sprintf("%d", yearday-1)
+		},
+		// the nth week of a year
+		'W': func(t time.Time) string {
+			_, week := t.ISOWeek()
+			return fmt.Sprintf("%d", week)
+		},
+		// check if is leap year
+		'L': func(t time.Time) string {
+			if isLeapYear(t.Year()) {
+				return "1"
+			}
+			return "0"
+		},
+		// get how many days of the month
+		't': func(t time.Time) string {
+			return fmt.Sprintf("%d", daysInMonth(t.Year(), t.Month()))
+		},
+		// get the hour of the time, 2 digits with leading zero
+		'H': func(t time.Time) string {
+			hour := t.Hour()
+			return fmt.Sprintf("%02d", hour)
+		},
+		// get the microseconds of the time
+		'u': func(t time.Time) string {
+			nano := t.Nanosecond()
+			return fmt.Sprintf("%06d", nano/1e3)
+		},
+		// get the milliseconds of the time
+		'v': func(t time.Time) string {
+			nano := t.Nanosecond()
+			return fmt.Sprintf("%03d", nano/1e6)
+		},
+		// the english ordinal suffix of the day, "st", "nd", "rd" or "th"
+		'S': func(t time.Time) string {
+			return englishOrdinal(t.Day())
+		},
 	}
-	formats := map[string]string{
-		// am, pm
-		"a": "pm",
-		"A": "PM",
-		// month
-		"F": "January",
-		"M": "Jan",
-		// week
-		"D": "Mon",
-		"l": "Monday",
-	}
-	// weekday, from monday to sunday
-	// monday return 1, sunday return 7
-	// it's the same as golang's weekday of time.Time.
-	N := func(t time.Time) string {
-		weekday := t.Weekday()
-		return fmt.Sprintf("%d", int(weekday))
-	}
-	// weekday, from sunday to saturday
-	// sunday return 0, saturday return 6
-	w := func(t time.Time) string {
-		weekday := t.Weekday()
-		dayNum := (int(weekday) + 1) % 7
-		return fmt.Sprintf("%d", dayNum)
-	}
-	// the day of the year, from 0 to 365
-	// the golang's yearday is from 1 to 366
-	// so here need reduce by one day
-	z := func(t time.Time) string {
-		yearday := t.YearDay()
-		return fmt.Sprintf("%d", yearday-1)
-	}
-	// the nth week of a year
-	W := func(t time.Time) string {
-		_, week := t.ISOWeek()
-		return fmt.Sprintf("%d", week)
-	}
-	// check if is leap year
-	L := func(t time.Time) string {
-		yearday := t.YearDay()
-		if yearday > 365 {
-			return "1"
-		}
-		return "0"
-	}
-	// get how many days of the month
-	t := func(t time.Time) string {
-		nums := [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
-		// if is leap Year, the second month has 29 days.
-		monthIndex := int(t.Month()) - 1
-		if monthIndex == 1 && t.YearDay() > 365 {
-			return "29"
-		}
-		return fmt.Sprintf("%d", nums[monthIndex])
-	}
-	// get the hour of the time, 2 digits with leading zero
-	H := func(t time.Time) string {
-		hour := t.Hour()
-		return fmt.Sprintf("%02d", hour)
-	}
-	// get the microseconds of the time
-	u := func(t time.Time) string {
-		nano := t.Nanosecond()
-		return fmt.Sprintf("%06d", nano/1e3)
-	}
-	// get the milliseconds of the time
-	v := func(t time.Time) string {
-		nano := t.Nanosecond()
-		return fmt.Sprintf("%03d", nano/1e6)
-	}
-	fns := map[string]func(t time.Time) string{
-		"N": N,
-		"w": w,
-		"z": z,
-		"W": W,
-		"L": L,
-		"t": t,
-		"H": H,
-		"u": u,
-		"v": v,
-	}
-	// numbers in date can format first by the golang layout
-	// "Y-m-da" will translate into -> '2006-01-02a'
-	repRule := strings.NewReplacer(layouts...)
-	layout := repRule.Replace(format)
-	// The other format keyword such as before 'a'
-	// Will use golang layout format or functions
-	regRule := func() *regexp.Regexp {
-		var str strings.Builder
-		str.WriteString("[")
-		for key := range formats {
-			str.WriteString(key)
-		}
-		for key := range fns {
-			str.WriteString(key)
-		}
-		str.WriteString("]")
-		rule, _ := regexp.Compile(str.String())
-		return rule
-	}()
+)
+
+// format the time with a format character
+// if the character is not a format keyword, return false
+func formatChar(t time.Time, ch byte) (string, bool) {
+	if layout, ok := formatLayouts[ch]; ok {
+		return t.Format(layout), true
+	} else if fn, ok := formatFns[ch]; ok {
+		return fn(t), true
+	}
+	return "", false
+}
+
+// DateFormat func
+func DateFormat(target interface{}, format string, opts ...Option) (string, error) {
+	o := makeOptions(opts)
 	// Change target to time struct
 	var timeTarget time.Time
 	if cur, ok := target.(time.Time); ok {
 		timeTarget = cur
 	} else {
-		if cur, err := DateTime(target); err == nil {
+		if cur, err := DateTime(target, opts...); err == nil {
 			timeTarget = cur
 		} else {
 			return "", err
 		}
 	}
-	// Format date numbers first, no letter characters will making
-	result := timeTarget.Format(layout)
-	// Replace the keyword letter character into real value
-	result = regRule.ReplaceAllStringFunc(result, func(name string) string {
-		if layout, ok := formats[name]; ok {
-			return timeTarget.Format(layout)
-		} else if fn, ok := fns[name]; ok {
-			return fn(timeTarget)
+	var result strings.Builder
+	// the genitive month names of the locale are used with a day
+	withDay := o.locale != nil && hasDayChar(format)
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		// escape the next character
+		if ch == '\\' && i+1 < len(format) {
+			i++
+			result.WriteByte(format[i])
+			continue
+		}
+		// the opt-in characters of the options
+		if o.fiscal != nil {
+			if value, ok := o.fiscal.formatChar(timeTarget, ch); ok {
+				result.WriteString(value)
+				continue
+			}
 		}
-		return ""
-	})
-	return result, nil
+		if o.japaneseEra {
+			if value, ok := formatJapaneseEraChar(timeTarget, ch); ok {
+				result.WriteString(value)
+				continue
+			}
+		}
+		if o.calendarSystem != nil {
+			if value, ok := formatCalendarChar(o.calendarSystem, timeTarget, ch, o.locale); ok {
+				result.WriteString(value)
+				continue
+			}
+		}
+		if o.locale != nil {
+			if value, ok := o.locale.formatChar(timeTarget, ch, withDay); ok {
+				result.WriteString(value)
+				continue
+			}
+		}
+		// Replace the keyword letter character into real value
+		if value, ok := formatChar(timeTarget, ch); ok {
+			result.WriteString(value)
+		} else {
+			result.WriteByte(ch)
+		}
+	}
+	return result.String(), nil
+}
+
+// DateFormatLocale format the date like 'DateFormat' with the names of the registered locale,
+// e.g. "j F Y" is "5 сентября 2021" with "ru"
+func DateFormatLocale(target interface{}, format string, locale string, opts ...Option) (string, error) {
+	if _, ok := GetLocale(locale); !ok {
+		return "", fmt.Errorf("wrong locale:'%s'", locale)
+	}
+	return DateFormat(target, format, append(append([]Option{}, opts...), WithLocale(locale))...)
 }
@@ -1,6 +1,7 @@
 package dateutil
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -142,6 +143,12 @@ func TestDateFormat(t *testing.T) {
 	} else {
 		assert.Fail(t, "Format day of the month without leading zeros 'j' is not ok.")
 	}
+	// English ordinal suffix for the day of the month
+	if S, err := DateFormat(curTime, "jS"); err == nil {
+		assert.Equal(t, S, "5th")
+	} else {
+		assert.Fail(t, "Format english ordinal suffix 'S' is not ok.")
+	}
 	// A full textual representation of the day of the week
 	if l, err := DateFormat(curTime, "l"); err == nil {
 		assert.Equal(t, l, "Sunday")
@@ -238,6 +245,38 @@ func TestDateFormat(t *testing.T) {
 	} else {
 		assert.Fail(t, "Format milliseconds 'v' is not ok.")
 	}
+	/*
+	* Test the formats keep the same outputs of the characters
+	 */
+	formats := map[string]string{
+		"Y-m-d H:i:s":       "2021-09-05 18:07:06",
+		"D, d M Y H:i:s":    "Sun, 05 Sep 2021 18:07:06",
+		"l, F j, Y g:i a":   "Sunday, September 5, 2021 6:07 pm",
+		"y/n/j G:i A":       "21/9/5 18:07 PM",
+		"N w z W t L":       "0 1 247 35 30 0",
+		"Ymd His.u":         "20210905 180706.012345",
+		"Y年m月d日 h时i分s秒":     "2021年09月05日 06时07分06秒",
+		"[Y] (m) {d} <H:i>": "[2021] (09) {05} <18:07>",
+	}
+	for format, expect := range formats {
+		if value, err := DateFormat(curTime, format); err == nil {
+			assert.Equal(t, value, expect, format)
+		} else {
+			assert.Fail(t, "Format '"+format+"' is not ok.")
+		}
+	}
+	// the escaped characters and the literal digits are kept like php
+	if value, err := DateFormat(curTime, "Y-m-d\\TH:i:s \\Y 2006"); err == nil {
+		assert.Equal(t, value, "2021-09-05T18:07:06 Y 2006")
+	} else {
+		assert.Fail(t, "Format the escaped characters is not ok.")
+	}
+	// the days of the february in the leap year
+	if value, err := DateFormat(time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC), "t L"); err == nil {
+		assert.Equal(t, value, "29 1")
+	} else {
+		assert.Fail(t, "Format the leap year 't L' is not ok.")
+	}
 }
 
 func TestNumberDate(t *testing.T) {
@@ -863,6 +902,33 @@ func TestStringDateTime(t *testing.T) {
 	} else {
 		assert.Fail(t, "StrToTime 2021/09/05T18:07:06.123456789 fail")
 	}
+	// zulu time
+	if date, err := DateTime("2021-09-05T10:07:06Z"); err == nil {
+		assert.Equal(t, date.UTC().Format("2006-01-02 15:04:05"), "2021-09-05 10:07:06")
+	} else {
+		assert.Fail(t, "StrToTime 2021-09-05T10:07:06Z fail")
+	}
+	if date, err := DateTime("2021-09-05T10:07Z"); err == nil {
+		assert.Equal(t, date.UTC().Format("2006-01-02 15:04:05"), "2021-09-05 10:07:00")
+	} else {
+		assert.Fail(t, "StrToTime 2021-09-05T10:07Z fail")
+	}
+	// seconds with fraction and timezone
+	if date, err := DateTime("2021-09-05T18:07:06.012345678+08:00"); err == nil {
+		assert.Equal(t, date.UTC().Format("2006-01-02 15:04:05.000000000"), "2021-09-05 10:07:06.012345678")
+	} else {
+		assert.Fail(t, "StrToTime 2021-09-05T18:07:06.012345678+08:00 fail")
+	}
+	// the timezone abbreviations of strptime's '%Z' are not the locations
+	if _, err := DateTime("2021-09-05 18:07:06 CEST"); err == nil {
+		assert.Fail(t, "StrToTime 2021-09-05 18:07:06 CEST ok")
+	}
+	// with location option
+	if date, err := DateTime("2021-09-05 18:07:06", WithLocation(localLocation)); err == nil {
+		assert.Equal(t, date, time.Date(2021, time.September, 5, 18, 7, 6, 0, localLocation))
+	} else {
+		assert.Fail(t, "StrToTime WithLocation fail")
+	}
 }
 
 func TestStrToTime(t *testing.T) {
@@ -890,3 +956,94 @@ func TestStrToTime(t *testing.T) {
 		assert.Fail(t, "StrToTime float64 fail")
 	}
 }
+
+func TestMonthNames(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]string{
+		"Sep 5, 2021":  "2021-09-05",
+		"Sept 5, 2021": "2021-09-05",
+		"Oct 3, 2021":  "2021-10-03",
+		"Nov 3, 2021":  "2021-11-03",
+		"Dec 28, 2021": "2021-12-28",
+		"22 XII 1978":  "1978-12-22",
+		"14 III 1879":  "1879-03-14",
+		"9 IX 2021":    "2021-09-09",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc); err == nil {
+			assert.Equal(t, date.Format("2006-01-02"), expect)
+		} else {
+			assert.Fail(t, "DateTime '"+value+"' fail")
+		}
+	}
+}
+
+func TestMonthNameNum(t *testing.T) {
+	// all the names of the month expression, the short names after "sep" are shifted by "sept"
+	expects := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 1, 2, 3, 4, 5, 6, 7, 8, 9, 9, 10, 11, 12, 8, 3, 7, 12, 4, 6, 9, 11, 2, 1, 5, 10}
+	if assert.Equal(t, len(allMonthExp), len(expects)) {
+		for index, name := range allMonthExp {
+			assert.Equal(t, monthNameNum(name), expects[index], name)
+			assert.Equal(t, monthNameNum(strings.ToUpper(name)), expects[index], name)
+		}
+	}
+	assert.Equal(t, monthNameNum("septem"), 0)
+	assert.Equal(t, monthNameNum("xiii"), 0)
+}
+
+func TestCJKDateTime(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]string{
+		"2021年9月5日":            "2021-09-05 00:00:00",
+		"2021年09月05号":          "2021-09-05 00:00:00",
+		"2021년 9월 5일":          "2021-09-05 00:00:00",
+		"2021年9月5日 18时07分":     "2021-09-05 18:07:00",
+		"2021年9月5日18時07分06秒":   "2021-09-05 18:07:06",
+		"2021年9月5日 下午6时07分":    "2021-09-05 18:07:00",
+		"2021年9月5日 午前6時":       "2021-09-05 06:00:00",
+		"2021年9月5日 上午12时":      "2021-09-05 00:00:00",
+		"2021年9月5日 下午12时":      "2021-09-05 12:00:00",
+		"2021年9月5日 午前12時":      "2021-09-05 00:00:00",
+		"2021년 9월 5일 오후 6시 7분": "2021-09-05 18:07:00",
+		"2021年9月5日 下午6:07":     "2021-09-05 18:07:00",
+		"２０２１年９月５日　１８：０７":      "2021-09-05 18:07:00",
+		"２０２１／０９／０５":           "2021-09-05 00:00:00",
+		"2021-09-05 18：07：06":  "2021-09-05 18:07:06",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc); err == nil {
+			assert.Equal(t, date.Format("2006-01-02 15:04:05"), expect, value)
+		} else {
+			assert.Fail(t, "DateTime '"+value+"' fail")
+		}
+	}
+	// the month and the year
+	if result, err := Parse("2021年9月", utc); err == nil {
+		assert.Equal(t, result.Time.Format("2006-01"), "2021-09")
+		assert.Equal(t, result.Precision, PrecisionMonth)
+	} else {
+		assert.Fail(t, "Parse CJK month fail")
+	}
+	if result, err := Parse("2021年", utc); err == nil {
+		assert.Equal(t, result.Time.Year(), 2021)
+		assert.Equal(t, result.Precision, PrecisionYear)
+	} else {
+		assert.Fail(t, "Parse CJK year fail")
+	}
+	// the short years are durations rather than the years, e.g. "5年" is five years
+	for _, value := range []string{"5年", "12年", "110年9月5日"} {
+		if _, err := DateTime(value, utc); err == nil {
+			assert.Fail(t, "DateTime '"+value+"' should fail")
+		}
+	}
+	if date, err := DateTime("9月5日", utc, WithLocale("zh")); err == nil {
+		assert.Equal(t, date.Format("01-02"), "09-05")
+	} else {
+		assert.Fail(t, "DateTime CJK month day fail")
+	}
+	if date, err := DateTime("下午6时", utc); err == nil {
+		assert.Equal(t, date.Hour(), 18)
+	} else {
+		assert.Fail(t, "DateTime CJK time fail")
+	}
+}
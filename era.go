@@ -0,0 +1,195 @@
+package dateutil
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JapaneseEra an era of the japanese calendar, the first year of an era is the year it starts in
+type JapaneseEra struct {
+	// Name the kanji name, e.g. "令和"
+	Name string
+	// Romaji the romanized name, the initial is also used in parsing, e.g. "Reiwa" and "R"
+	Romaji string
+	// Start the first day of the era
+	Start time.Time
+}
+
+var (
+	// the eras ordered by the start day
+	japaneseEras = []JapaneseEra{
+		{Name: "明治", Romaji: "Meiji", Start: time.Date(1868, time.October, 23, 0, 0, 0, 0, time.UTC)},
+		{Name: "大正", Romaji: "Taisho", Start: time.Date(1912, time.July, 30, 0, 0, 0, 0, time.UTC)},
+		{Name: "昭和", Romaji: "Showa", Start: time.Date(1926, time.December, 25, 0, 0, 0, 0, time.UTC)},
+		{Name: "平成", Romaji: "Heisei", Start: time.Date(1989, time.January, 8, 0, 0, 0, 0, time.UTC)},
+		{Name: "令和", Romaji: "Reiwa", Start: time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	eraMutex sync.RWMutex
+)
+
+// get the initial of the romanized name, e.g. "R" of "Reiwa"
+func (era JapaneseEra) initial() string {
+	if era.Romaji == "" {
+		return ""
+	}
+	return strings.ToUpper(era.Romaji[:1])
+}
+
+// RegisterJapaneseEra add a new era or replace the era with the same kanji name
+func RegisterJapaneseEra(era JapaneseEra) {
+	eraMutex.Lock()
+	replaced := false
+	for index, cur := range japaneseEras {
+		if cur.Name == era.Name {
+			japaneseEras[index] = era
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		japaneseEras = append(japaneseEras, era)
+	}
+	sort.SliceStable(japaneseEras, func(i, j int) bool {
+		return japaneseEras[i].Start.Before(japaneseEras[j].Start)
+	})
+	eraMutex.Unlock()
+	// remove the date patterns made by the previous eras
+	patternMutex.Lock()
+	dateFormats["era"] = japaneseEraExp()
+	for key := range allPatternInfo {
+		if key == "date" || strings.HasPrefix(key, "date@") {
+			delete(allPatternInfo, key)
+		}
+	}
+	patternMutex.Unlock()
+	findMutex.Lock()
+	localeFindPatterns = map[string][]findPattern{}
+	findMutex.Unlock()
+}
+
+// JapaneseEras get the registered eras ordered by the start day
+func JapaneseEras() []JapaneseEra {
+	eraMutex.RLock()
+	defer eraMutex.RUnlock()
+	return append([]JapaneseEra{}, japaneseEras...)
+}
+
+// JapaneseEraOf get the era and the year of the era, false if the time is before all the eras
+func JapaneseEraOf(t time.Time) (JapaneseEra, int, bool) {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	eraMutex.RLock()
+	defer eraMutex.RUnlock()
+	for index := len(japaneseEras) - 1; index >= 0; index-- {
+		if era := japaneseEras[index]; !date.Before(era.Start) {
+			return era, year - era.Start.Year() + 1, true
+		}
+	}
+	return JapaneseEra{}, 0, false
+}
+
+// JapaneseYear get the gregorian year by the era name and the year of the era,
+// the name can be the kanji name, the romanized name or the initial, e.g. "令和", "Reiwa", "R"
+func JapaneseYear(name string, year int) (int, error) {
+	era, end, ok := findJapaneseEra(name)
+	if !ok {
+		return 0, fmt.Errorf("wrong japanese era:'%s'", name)
+	}
+	gregorian := era.Start.Year() + year - 1
+	if year < 1 || (!end.IsZero() && gregorian > end.AddDate(0, 0, -1).Year()) {
+		return 0, fmt.Errorf("wrong japanese era year:'%d'", year)
+	}
+	return gregorian, nil
+}
+
+// find the era by the name and the start day of the next era, the end is zero for the latest era,
+// the latest era is used if the initials are the same
+func findJapaneseEra(name string) (JapaneseEra, time.Time, bool) {
+	eraMutex.RLock()
+	defer eraMutex.RUnlock()
+	for index := len(japaneseEras) - 1; index >= 0; index-- {
+		era := japaneseEras[index]
+		if era.Name == name || strings.EqualFold(era.Romaji, name) || strings.EqualFold(era.initial(), name) {
+			var end time.Time
+			if index+1 < len(japaneseEras) {
+				end = japaneseEras[index+1].Start
+			}
+			return era, end, true
+		}
+	}
+	return JapaneseEra{}, time.Time{}, false
+}
+
+// the expression of the era names for the date rules
+func japaneseEraExp() string {
+	eraMutex.RLock()
+	defer eraMutex.RUnlock()
+	names := make([][]string, len(japaneseEras))
+	for index, era := range japaneseEras {
+		names[index] = []string{era.Name, era.Romaji, era.initial()}
+	}
+	return "(" + strings.Join(flattenNames(names), "|") + ")"
+}
+
+// change the era and the year of the era in the format result to the gregorian year,
+// e.g. "令和元年" is 2019, return false if the era is unknown or the date is out of the era
+func resolveJapaneseEra(result FormatResult) bool {
+	name := result["era"]
+	if name == "" {
+		return true
+	}
+	year := 1
+	if cur := result["ey"]; cur != "元" {
+		year, _ = strconv.Atoi(cur)
+	}
+	gregorian, err := JapaneseYear(name, year)
+	if err != nil {
+		return false
+	}
+	// the days covered by the present fields, e.g. "平成31年5月" is out of heisei
+	first := time.Date(gregorian, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(1, 0, -1)
+	month := 0
+	if cur := noEmptyField(result, "MM", "mm"); cur != "" {
+		month, _ = strconv.Atoi(cur)
+	} else if cur := noEmptyField(result, "M", "m"); cur != "" {
+		month = monthNameNum(cur)
+	}
+	if month >= 1 && month <= 12 {
+		first = time.Date(gregorian, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		last = first.AddDate(0, 1, -1)
+		if cur := noEmptyField(result, "DD", "dd"); cur != "" {
+			day, _ := strconv.Atoi(cur)
+			first = time.Date(gregorian, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+			last = first
+		}
+	}
+	era, end, _ := findJapaneseEra(name)
+	if last.Before(era.Start) || (!end.IsZero() && !first.Before(end)) {
+		return false
+	}
+	delete(result, "era")
+	delete(result, "ey")
+	result["YY"] = fmt.Sprintf("%04d", gregorian)
+	return true
+}
+
+// format the japanese era characters, 'E' the era name, 'J' the year of the era
+// the name is empty and the year is the gregorian year if the time is before all the eras
+func formatJapaneseEraChar(t time.Time, ch byte) (string, bool) {
+	if ch != 'E' && ch != 'J' {
+		return "", false
+	}
+	era, year, ok := JapaneseEraOf(t)
+	if ch == 'E' {
+		return era.Name, true
+	}
+	if !ok {
+		year = t.Year()
+	}
+	return strconv.Itoa(year), true
+}
@@ -0,0 +1,104 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJapaneseEra(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]string{
+		"令和3年9月5日":          "2021-09-05 00:00:00",
+		"令和元年5月1日":          "2019-05-01 00:00:00",
+		"平成31年4月30日 18時07分": "2019-04-30 18:07:00",
+		"昭和64年1月7日":         "1989-01-07 00:00:00",
+		"R3.9.5":            "2021-09-05 00:00:00",
+		"H31/04/30":         "2019-04-30 00:00:00",
+		"S64.1.7":           "1989-01-07 00:00:00",
+		"Reiwa 3-9-5":       "2021-09-05 00:00:00",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc); err == nil {
+			assert.Equal(t, date.Format("2006-01-02 15:04:05"), expect, value)
+		} else {
+			assert.Fail(t, "DateTime '"+value+"' fail")
+		}
+	}
+	if result, err := Parse("令和3年", utc); err == nil {
+		assert.Equal(t, result.Time.Year(), 2021)
+		assert.Equal(t, result.Precision, PrecisionYear)
+	} else {
+		assert.Fail(t, "Parse era year fail")
+	}
+	if _, err := DateTime("X3.9.5", utc); err == nil {
+		assert.Fail(t, "DateTime unknown era ok")
+	}
+	// the dates out of the eras
+	for _, value := range []string{"令和1年1月1日", "令和元年4月30日", "平成31年5月1日", "平成32年", "M45.7.30", "大正15年12月25日"} {
+		if date, err := DateTime(value, utc); err == nil {
+			assert.Fail(t, "DateTime out of era '"+value+"' ok: "+date.Format("2006-01-02"))
+		}
+	}
+	if result, err := Parse("平成31年4月", utc); err == nil {
+		assert.Equal(t, result.Time.Format("2006-01"), "2019-04")
+	} else {
+		assert.Fail(t, "Parse era last month fail")
+	}
+	// the conversions
+	if era, year, ok := JapaneseEraOf(time.Date(2019, time.April, 30, 23, 0, 0, 0, time.UTC)); ok {
+		assert.Equal(t, era.Name, "平成")
+		assert.Equal(t, year, 31)
+	} else {
+		assert.Fail(t, "JapaneseEraOf fail")
+	}
+	if _, _, ok := JapaneseEraOf(time.Date(1800, time.January, 1, 0, 0, 0, 0, time.UTC)); ok {
+		assert.Fail(t, "JapaneseEraOf before all eras ok")
+	}
+	if year, err := JapaneseYear("Showa", 64); err == nil {
+		assert.Equal(t, year, 1989)
+	} else {
+		assert.Fail(t, "JapaneseYear fail")
+	}
+	if _, err := JapaneseYear("令和", 0); err == nil {
+		assert.Fail(t, "JapaneseYear zero year ok")
+	}
+	if _, err := JapaneseYear("Heisei", 32); err == nil {
+		assert.Fail(t, "JapaneseYear out of era ok")
+	}
+	// the format characters
+	date := time.Date(2021, time.September, 5, 0, 0, 0, 0, time.UTC)
+	if value, err := DateFormat(date, "EJ年n月j日", WithJapaneseEra()); err == nil {
+		assert.Equal(t, value, "令和3年9月5日")
+	} else {
+		assert.Fail(t, "DateFormat era fail")
+	}
+	if value, err := DateFormat(date, "EJ"); err == nil {
+		assert.Equal(t, value, "EJ")
+	} else {
+		assert.Fail(t, "DateFormat without era fail")
+	}
+}
+
+func TestRegisterJapaneseEra(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	RegisterJapaneseEra(JapaneseEra{Name: "仮元", Romaji: "Kagen", Start: time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)})
+	eras := JapaneseEras()
+	assert.Equal(t, eras[len(eras)-1].Name, "仮元")
+	if date, err := DateTime("仮元2年3月4日", utc); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2101-03-04")
+	} else {
+		assert.Fail(t, "DateTime registered era fail")
+	}
+	if date, err := DateTime("K2.3.4", utc); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2101-03-04")
+	} else {
+		assert.Fail(t, "DateTime registered era initial fail")
+	}
+	if value, err := DateFormat(time.Date(2100, time.December, 31, 0, 0, 0, 0, time.UTC), "EJ", WithJapaneseEra()); err == nil {
+		assert.Equal(t, value, "仮元1")
+	} else {
+		assert.Fail(t, "DateFormat registered era fail")
+	}
+}
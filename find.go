@@ -0,0 +1,242 @@
+package dateutil
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match a datetime found in the text
+type Match struct {
+	// Start the byte offset of the first character in the text
+	Start int
+	// End the byte offset after the last character in the text
+	End int
+	// Text the matched datetime string
+	Text string
+	ParseResult
+}
+
+// the unanchored pattern for finding the datetimes
+type findPattern struct {
+	kind string
+	rule *regexp.Regexp
+}
+
+// the span of a candidate in the text, the time part is set if it's a date followed by a time
+type findSpan struct {
+	start     int
+	end       int
+	dateEnd   int
+	timeStart int
+}
+
+var (
+	// the patterns by the locale name, empty for no locale
+	localeFindPatterns = map[string][]findPattern{}
+	findMutex          sync.Mutex
+	// the characters between the date and the time, e.g. "2021-09-05 10:00", "2021-09-05T10:00", "Sep 5, 2021 at 10am"
+	findJoinRule = regexp.MustCompile("(?i)^(?:[ \\t]*,?[ \\t]*|[ \\t]+at[ \\t]+)$")
+	// the time without a date must have a colon, a meridian or a CJK unit, so the numbers like "1.5", "1230" are not times
+	findTimeRule = regexp.MustCompile(":|[AaPp]\\.?[Mm]\\.?$|[时時시点點]")
+	// the years without other fields, from 1800 to 2099
+	findYearRule = regexp.MustCompile("^(?:18|19|20)[0-9]{2}$")
+)
+
+// get the unanchored patterns of the rfc, date and time rules
+func getFindPatterns(locale *Locale) []findPattern {
+	name := ""
+	if locale != nil {
+		name = strings.ToLower(locale.Name)
+	}
+	findMutex.Lock()
+	defer findMutex.Unlock()
+	if findPatterns, ok := localeFindPatterns[name]; ok {
+		return findPatterns
+	}
+	var findPatterns []findPattern
+	for _, kind := range []struct {
+		key   string
+		rules []string
+	}{{RFCSYMBOL, rfcRules}, {"date", dateRules}, {"time", timeRules}} {
+		for _, pattern := range getPatterns(kind.key, kind.rules, locale) {
+			rule := pattern.Rule.String()
+			if strings.HasPrefix(rule, "^") {
+				rule = rule[1:]
+			} else if strings.HasPrefix(rule, "(?i)^") {
+				rule = "(?i)" + rule[5:]
+			}
+			findPatterns = append(findPatterns, findPattern{
+				kind: kind.key,
+				rule: regexp.MustCompile(strings.TrimSuffix(rule, "$")),
+			})
+		}
+	}
+	localeFindPatterns[name] = findPatterns
+	return findPatterns
+}
+
+// FindAll find all the datetimes in the text, ordered by the position
+// the datetimes must be whole words, the longest one is chosen if the matches overlap
+// a date followed by a time is matched as a datetime, e.g. "Sep 5, 2021 at 10am"
+func FindAll(text string, opts ...Option) []Match {
+	o := makeOptions(opts)
+	var spans, dates, times []findSpan
+	for _, pattern := range getFindPatterns(o.locale) {
+		for _, loc := range pattern.rule.FindAllStringIndex(text, -1) {
+			value := strings.TrimRight(text[loc[0]:loc[1]], " \t,")
+			// the full stop after the meridian, e.g. "at 3pm."
+			if n := len(value); n > 2 && (value[n-2] == 'm' || value[n-2] == 'M') && value[n-1] == '.' && value[n-3] != '.' {
+				value = value[:n-1]
+			}
+			end := loc[0] + len(value)
+			if end <= loc[0] {
+				continue
+			}
+			span := findSpan{
+				start: loc[0],
+				end:   end,
+			}
+			spans = append(spans, span)
+			switch pattern.kind {
+			case "date":
+				dates = append(dates, span)
+			case "time":
+				times = append(times, span)
+			}
+		}
+	}
+	for _, date := range dates {
+		// the year only date can't have a time, e.g. "1234 1230"
+		if yearOnlyRule.MatchString(text[date.start:date.end]) {
+			continue
+		}
+		for _, cur := range times {
+			if cur.start >= date.end && findJoinRule.MatchString(text[date.end:cur.start]) {
+				spans = append(spans, findSpan{
+					start:     date.start,
+					end:       cur.end,
+					dateEnd:   date.end,
+					timeStart: cur.start,
+				})
+			}
+		}
+	}
+	// the longest first
+	sort.SliceStable(spans, func(i, j int) bool {
+		a, b := spans[i], spans[j]
+		if a.end-a.start != b.end-b.start {
+			return a.end-a.start > b.end-b.start
+		}
+		return a.start < b.start
+	})
+	var matches []Match
+	for _, span := range spans {
+		if !isWordBoundary(text, span.start, span.end) || isIdentifierPart(text, span.start, span.end) {
+			continue
+		}
+		overlapped := false
+		for _, match := range matches {
+			if span.start < match.End && match.Start < span.end {
+				overlapped = true
+				break
+			}
+		}
+		if overlapped {
+			continue
+		}
+		value := text[span.start:span.end]
+		if span.dateEnd > 0 {
+			value = text[span.start:span.dateEnd] + " " + text[span.timeStart:span.end]
+		}
+		if result, ok := parseFound(value, o); ok {
+			matches = append(matches, Match{
+				Start:       span.start,
+				End:         span.end,
+				Text:        text[span.start:span.end],
+				ParseResult: *result,
+			})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Start < matches[j].Start
+	})
+	return matches
+}
+
+// parse the found datetime, skip the words and numbers which are not likely datetimes
+func parseFound(value string, o *options) (*ParseResult, bool) {
+	if !strings.ContainsAny(value, "0123456789") {
+		return nil, false
+	}
+	var (
+		lasts    FormatResult
+		patterns []*Pattern
+	)
+	if yearOnlyRule.MatchString(value) {
+		// four digits are a year rather than a time, skip the other numbers like "1234"
+		if !findYearRule.MatchString(value) {
+			return nil, false
+		}
+		pattern, result, _ := matchDateFormat(value, o.locale)
+		lasts, patterns = result, []*Pattern{pattern}
+	} else {
+		var err error
+		if lasts, patterns, err = matchOptionsDateTime(value, o); err != nil || isResultTimezone(lasts) {
+			return nil, false
+		}
+	}
+	// the roman months must be uppercase, and 'I' is more likely the pronoun
+	if month := noEmptyField(lasts, "m"); month != "" && strings.Trim(strings.ToLower(month), "ivx") == "" {
+		if month != strings.ToUpper(month) || month == "I" {
+			return nil, false
+		}
+	}
+	result, err := makeParseResult(lasts, patterns, o)
+	if err != nil || (!result.HasDate && !findTimeRule.MatchString(value)) {
+		return nil, false
+	}
+	return result, true
+}
+
+// check if the span is a whole word in the text
+// the CJK texts have no spaces between the words, so the CJK characters are boundaries
+func isWordBoundary(text string, start, end int) bool {
+	if start > 0 {
+		if prev, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(prev) {
+			return false
+		}
+	}
+	if end < len(text) {
+		if next, _ := utf8.DecodeRuneInString(text[end:]); isWordRune(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// check if the span is a part of an identifier, e.g. "2021" of "Invoice #2021-0042",
+// the numbers after '#' are not datetimes, and the years joined to other numbers by '-' are not years
+func isIdentifierPart(text string, start, end int) bool {
+	if start > 0 && text[start-1] == '#' {
+		return true
+	}
+	if !yearOnlyRule.MatchString(text[start:end]) {
+		return false
+	}
+	if start > 1 && text[start-1] == '-' && isDigit(text[start-2]) {
+		return true
+	}
+	return end+1 < len(text) && text[end] == '-' && isDigit(text[end+1])
+}
+
+// check if the rune is a part of a word, the CJK characters are not
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
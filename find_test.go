@@ -0,0 +1,61 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAll(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	text := "The meeting moved from 2021-09-05 10:00 to Sep 7, 2021 at 3pm. Call me at 4:30pm, not 1.5 or order 1234. " +
+		"Released in 2019. Deadline: 2021-09-30T18:00:00Z, see XII 1978 and 22 Dec 2021. Version 3.14 and 05/12."
+	expects := []struct {
+		text      string
+		precision Precision
+	}{
+		{"2021-09-05 10:00", PrecisionMinute},
+		{"Sep 7, 2021 at 3pm", PrecisionHour},
+		{"4:30pm", PrecisionMinute},
+		{"2019", PrecisionYear},
+		{"2021-09-30T18:00:00Z", PrecisionSecond},
+		{"XII 1978", PrecisionMonth},
+		{"22 Dec 2021", PrecisionDay},
+		{"05/12", PrecisionDay},
+	}
+	matches := FindAll(text, utc)
+	if assert.Equal(t, len(matches), len(expects)) {
+		for index, match := range matches {
+			assert.Equal(t, match.Text, expects[index].text)
+			assert.Equal(t, text[match.Start:match.End], match.Text)
+			assert.Equal(t, match.Precision, expects[index].precision, match.Text)
+		}
+		assert.Equal(t, matches[1].Time.Format("2006-01-02 15:04"), "2021-09-07 15:00")
+		assert.Equal(t, matches[1].Rule, "(?i)^${m}[ .\\t-]*${dd}[,.stndrh\\t ]+${y}")
+		assert.Equal(t, matches[4].Time.Format("2006-01-02 15:04:05"), "2021-09-30 18:00:00")
+		assert.True(t, matches[4].HasZone)
+	}
+	// the words and the numbers
+	for _, text := range []string{"", "I may march", "version 1.2 and 3.14", "order 1234 at 1230", "20210905abc", "x2021-09-05", "Invoice #2021-0042", "ticket 0042-2021", "see #2021"} {
+		assert.Equal(t, len(FindAll(text, utc)), 0, text)
+	}
+	// the multibyte characters
+	if matches := FindAll("日期：2021-09-05，时间 10:30", utc); assert.Equal(t, len(matches), 2) {
+		assert.Equal(t, matches[0].Start, len("日期："))
+		assert.Equal(t, matches[0].Text, "2021-09-05")
+		assert.Equal(t, matches[1].Text, "10:30")
+	}
+	// the CJK texts without the spaces
+	if matches := FindAll("会议在2021年9月5日下午6时举行，截止9月1日。", utc); assert.Equal(t, len(matches), 2) {
+		assert.Equal(t, matches[0].Text, "2021年9月5日下午6时")
+		assert.Equal(t, matches[0].Time.Format("2006-01-02 15:04"), "2021-09-05 18:00")
+		assert.Equal(t, matches[1].Text, "9月1日")
+	}
+	// the noon of the 12-hour clock
+	if matches := FindAll("lunch on Sep 5, 2021 at 12pm", utc); assert.Equal(t, len(matches), 1) {
+		assert.Equal(t, matches[0].Time.Format("2006-01-02 15:04"), "2021-09-05 12:00")
+	}
+	// the durations are not the years
+	assert.Equal(t, len(FindAll("他在公司工作了5年，合同期限12年。", utc)), 0)
+}
@@ -0,0 +1,184 @@
+package dateutil
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// FiscalCalendar the fiscal year which starts in any month, the periods can be the calendar months
+// or the retail weeks like 4-4-5
+type FiscalCalendar struct {
+	// StartMonth the first month of the fiscal year, default January
+	StartMonth time.Month
+	// YearByStart name the fiscal year by the calendar year it starts in,
+	// default by the year it ends in, e.g. the fiscal year from 2021-04-01 to 2022-03-31 is FY2022
+	YearByStart bool
+	// Weeks the weeks of the three periods in a quarter, e.g. []int{4, 4, 5}, empty for the calendar months
+	// the week based year starts on the 'WeekStart' nearest to the first day of the 'StartMonth',
+	// the 53rd week of a long year belongs to the last period
+	Weeks []int
+	// WeekStart the first day of the weeks for the week based year
+	WeekStart time.Weekday
+}
+
+// FiscalDate the fiscal year, quarter from 1 to 4, period from 1 to 12 and week from 1 to 53
+type FiscalDate struct {
+	Year    int
+	Quarter int
+	Period  int
+	Week    int
+}
+
+var (
+	// "Q3 2021", "Q3 FY21", "Q3/2021"
+	fiscalQuarterYearRule = regexp.MustCompile("(?i)^Q([1-4])[ \\t]*[-/ \\t][ \\t]*(?:FY[ \\t]*)?([0-9]{4}|[0-9]{2})$")
+	// "FY2022 Q1", "FY22-Q1", "FY2022"
+	fiscalYearQuarterRule = regexp.MustCompile("(?i)^FY[ \\t]*([0-9]{4}|[0-9]{2})(?:[ \\t]*[-/ \\t][ \\t]*Q([1-4]))?$")
+)
+
+// get the month of the first month, default January
+func (calendar *FiscalCalendar) startMonth() time.Month {
+	if calendar.StartMonth < time.January || calendar.StartMonth > time.December {
+		return time.January
+	}
+	return calendar.StartMonth
+}
+
+// get the calendar year the fiscal year starts in
+func (calendar *FiscalCalendar) startYear(year int) int {
+	if calendar.YearByStart || calendar.startMonth() == time.January {
+		return year
+	}
+	return year - 1
+}
+
+// get the first day of the fiscal year by the calendar year it starts in, at midnight in UTC
+func (calendar *FiscalCalendar) yearStartOf(startYear int) time.Time {
+	first := time.Date(startYear, calendar.startMonth(), 1, 0, 0, 0, 0, time.UTC)
+	if len(calendar.Weeks) == 0 {
+		return first
+	}
+	// the nearest week start
+	days := (int(calendar.WeekStart) - int(first.Weekday()) + 7) % 7
+	if days > 3 {
+		days -= 7
+	}
+	return first.AddDate(0, 0, days)
+}
+
+// get the weeks of the period from 1 to 12
+func (calendar *FiscalCalendar) periodWeeks(period int) int {
+	return calendar.Weeks[(period-1)%len(calendar.Weeks)]
+}
+
+// FiscalDate get the fiscal year, quarter, period and week of the time
+func (calendar *FiscalCalendar) FiscalDate(t time.Time) FiscalDate {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	startYear := year
+	if start := calendar.yearStartOf(startYear + 1); !date.Before(start) {
+		startYear++
+	} else if date.Before(calendar.yearStartOf(startYear)) {
+		startYear--
+	}
+	start := calendar.yearStartOf(startYear)
+	days := daysBetween(start, date)
+	result := FiscalDate{
+		Year: startYear,
+		Week: days/7 + 1,
+	}
+	if !calendar.YearByStart && calendar.startMonth() != time.January {
+		result.Year++
+	}
+	if len(calendar.Weeks) == 0 {
+		result.Period = (int(month)-int(calendar.startMonth())+12)%12 + 1
+	} else {
+		weeks := result.Week
+		for result.Period = 1; result.Period < 12 && weeks > calendar.periodWeeks(result.Period); result.Period++ {
+			weeks -= calendar.periodWeeks(result.Period)
+		}
+	}
+	result.Quarter = (result.Period-1)/3 + 1
+	return result
+}
+
+// Start get the first instant of the fiscal date in the location
+// the start of the week if the week is set, or the period, or the quarter, or the year
+func (calendar *FiscalCalendar) Start(date FiscalDate, location *time.Location) time.Time {
+	start := calendar.yearStartOf(calendar.startYear(date.Year))
+	period := date.Period
+	if period == 0 && date.Quarter > 0 {
+		period = (date.Quarter-1)*3 + 1
+	}
+	switch {
+	case date.Week > 0:
+		start = start.AddDate(0, 0, (date.Week-1)*7)
+	case period > 0 && len(calendar.Weeks) == 0:
+		start = start.AddDate(0, period-1, 0)
+	case period > 0:
+		weeks := 0
+		for cur := 1; cur < period; cur++ {
+			weeks += calendar.periodWeeks(cur)
+		}
+		start = start.AddDate(0, 0, weeks*7)
+	}
+	year, month, day := start.Date()
+	return startOfDay(year, month, day, location)
+}
+
+// End get the last instant of the fiscal date in the location, the same unit as 'Start'
+func (calendar *FiscalCalendar) End(date FiscalDate, location *time.Location) time.Time {
+	next := FiscalDate{Year: date.Year + 1}
+	switch {
+	case date.Week > 0:
+		if date.Week < calendar.weeksOfYear(date.Year) {
+			next = FiscalDate{Year: date.Year, Week: date.Week + 1}
+		}
+	case date.Period > 0:
+		if date.Period < 12 {
+			next = FiscalDate{Year: date.Year, Period: date.Period + 1}
+		}
+	case date.Quarter > 0:
+		if date.Quarter < 4 {
+			next = FiscalDate{Year: date.Year, Quarter: date.Quarter + 1}
+		}
+	}
+	return calendar.Start(next, location).Add(-time.Nanosecond)
+}
+
+// get the weeks of the fiscal year
+func (calendar *FiscalCalendar) weeksOfYear(year int) int {
+	startYear := calendar.startYear(year)
+	return (daysBetween(calendar.yearStartOf(startYear), calendar.yearStartOf(startYear+1)) + 6) / 7
+}
+
+// parse the fiscal quarter or year, e.g. "Q3 2021", "FY2022 Q1", "FY2022"
+func (calendar *FiscalCalendar) parse(value string) (FiscalDate, bool) {
+	var year, quarter string
+	if matchs := fiscalQuarterYearRule.FindStringSubmatch(value); matchs != nil {
+		quarter, year = matchs[1], matchs[2]
+	} else if matchs := fiscalYearQuarterRule.FindStringSubmatch(value); matchs != nil {
+		year, quarter = matchs[1], matchs[2]
+	} else {
+		return FiscalDate{}, false
+	}
+	date := FiscalDate{}
+	date.Year, _ = strconv.Atoi(year)
+	if len(year) == 2 {
+		date.Year += 2000
+	}
+	date.Quarter, _ = strconv.Atoi(quarter)
+	return date, true
+}
+
+// format the fiscal characters, 'Q' the fiscal quarter, 'K' the fiscal year
+func (calendar *FiscalCalendar) formatChar(t time.Time, ch byte) (string, bool) {
+	switch ch {
+	case 'Q':
+		return strconv.Itoa(calendar.FiscalDate(t).Quarter), true
+	case 'K':
+		return strconv.Itoa(calendar.FiscalDate(t).Year), true
+	}
+	return "", false
+}
@@ -0,0 +1,77 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiscalCalendar(t *testing.T) {
+	layout := "2006-01-02 15:04:05.999999999"
+	date := time.Date(2021, time.September, 5, 18, 7, 6, 0, time.UTC)
+	// the fiscal year starts in april
+	april := &FiscalCalendar{StartMonth: time.April}
+	assert.Equal(t, april.FiscalDate(date), FiscalDate{Year: 2022, Quarter: 2, Period: 6, Week: 23})
+	assert.Equal(t, april.FiscalDate(time.Date(2022, time.March, 31, 0, 0, 0, 0, time.UTC)), FiscalDate{Year: 2022, Quarter: 4, Period: 12, Week: 53})
+	assert.Equal(t, april.Start(FiscalDate{Year: 2022}, time.UTC).Format(layout), "2021-04-01 00:00:00")
+	assert.Equal(t, april.Start(FiscalDate{Year: 2022, Quarter: 3}, time.UTC).Format(layout), "2021-10-01 00:00:00")
+	assert.Equal(t, april.End(FiscalDate{Year: 2022, Quarter: 4}, time.UTC).Format(layout), "2022-03-31 23:59:59.999999999")
+	assert.Equal(t, april.End(FiscalDate{Year: 2022, Period: 11}, time.UTC).Format(layout), "2022-02-28 23:59:59.999999999")
+	assert.Equal(t, april.End(FiscalDate{Year: 2022}, time.UTC).Format(layout), "2022-03-31 23:59:59.999999999")
+	// named by the start year
+	japan := &FiscalCalendar{StartMonth: time.April, YearByStart: true}
+	assert.Equal(t, japan.FiscalDate(date).Year, 2021)
+	assert.Equal(t, japan.Start(FiscalDate{Year: 2021}, localLocation).Format(layout+" -0700"), "2021-04-01 00:00:00 +0800")
+	// the calendar year
+	calendar := &FiscalCalendar{}
+	assert.Equal(t, calendar.FiscalDate(date), FiscalDate{Year: 2021, Quarter: 3, Period: 9, Week: 36})
+	// the retail 4-4-5 calendar, starts on the sunday nearest to february 1st
+	retail := &FiscalCalendar{StartMonth: time.February, YearByStart: true, Weeks: []int{4, 4, 5}, WeekStart: time.Sunday}
+	assert.Equal(t, retail.FiscalDate(date), FiscalDate{Year: 2021, Quarter: 3, Period: 8, Week: 32})
+	assert.Equal(t, retail.Start(FiscalDate{Year: 2021}, time.UTC).Format(layout), "2021-01-31 00:00:00")
+	assert.Equal(t, retail.Start(FiscalDate{Year: 2021, Period: 8}, time.UTC).Format(layout), "2021-08-29 00:00:00")
+	assert.Equal(t, retail.End(FiscalDate{Year: 2021, Quarter: 3}, time.UTC).Format(layout), "2021-10-30 23:59:59.999999999")
+	assert.Equal(t, retail.FiscalDate(time.Date(2021, time.January, 30, 0, 0, 0, 0, time.UTC)).Year, 2020)
+	// the 53 weeks year
+	assert.Equal(t, retail.weeksOfYear(2023), 53)
+	assert.Equal(t, retail.FiscalDate(time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC)), FiscalDate{Year: 2023, Quarter: 4, Period: 12, Week: 53})
+	assert.Equal(t, retail.End(FiscalDate{Year: 2023, Period: 12}, time.UTC).Format(layout), "2024-02-03 23:59:59.999999999")
+	assert.Equal(t, retail.End(FiscalDate{Year: 2023, Week: 53}, time.UTC).Format(layout), "2024-02-03 23:59:59.999999999")
+}
+
+func TestFiscalNotations(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	fiscal := WithFiscalCalendar(&FiscalCalendar{StartMonth: time.April})
+	cases := map[string]string{
+		"Q3 2021":    "2020-10-01",
+		"q1/2021":    "2020-04-01",
+		"Q4 FY22":    "2022-01-01",
+		"FY2022 Q1":  "2021-04-01",
+		"FY22-Q2":    "2021-07-01",
+		"fy 2022":    "2021-04-01",
+		"2021-09-05": "2021-09-05",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc, fiscal); err == nil {
+			assert.Equal(t, date.Format("2006-01-02"), expect)
+		} else {
+			assert.Fail(t, "DateTime fiscal '"+value+"' fail")
+		}
+	}
+	if _, err := DateTime("FY2022 Q1", utc); err == nil {
+		assert.Fail(t, "DateTime FY2022 Q1 without fiscal calendar ok")
+	}
+	// the format characters
+	date := time.Date(2021, time.September, 5, 0, 0, 0, 0, time.UTC)
+	if result, err := DateFormat(date, "\\F\\YK \\QQ Y-m-d", fiscal); err == nil {
+		assert.Equal(t, result, "FY2022 Q2 2021-09-05")
+	} else {
+		assert.Fail(t, "DateFormat fiscal fail")
+	}
+	if result, err := DateFormat(date, "Q K"); err == nil {
+		assert.Equal(t, result, "Q K")
+	} else {
+		assert.Fail(t, "DateFormat without fiscal fail")
+	}
+}
@@ -0,0 +1,159 @@
+package dateutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// the words split by the spaces, commas and semicolons
+	fuzzyWordRule = regexp.MustCompile("[^ \\t\\r\\n,;]+")
+	// "pm", "a.m."
+	fuzzyMeridianRule    = regexp.MustCompile("(?i)^[ap]\\.?m\\.?$")
+	fuzzyMeridianEndRule = regexp.MustCompile("(?i)[ap]\\.m\\.$")
+	// the words with numbers, e.g. "5th", "2021-09-05", "6pm", "+08:00"
+	fuzzyNumberRule = regexp.MustCompile("^[0-9A-Za-z:./+-]*[0-9][0-9A-Za-z:./+-]*$")
+	// the CJK dates, e.g. "110年9月5日", "9月5日"
+	fuzzyCJKDateRule = regexp.MustCompile("^(?:[0-9]+年(?:[0-9]+月(?:[0-9]+日)?)?|[0-9]+月[0-9]+日)$")
+	// the words of the time, e.g. "10:00", "6pm", "6p.m."
+	fuzzyTimeRule = regexp.MustCompile("(?i):|[0-9][ap]\\.?m\\.?$")
+	// the timezone abbreviations or names, e.g. "UTC", "Europe/Paris"
+	fuzzyZoneRule = regexp.MustCompile("^(?:[A-Z]{2,6}|[A-Z][a-z]+(?:[_/][A-Z][a-z]+)+)$")
+	// the words checked by 'isLocationName'
+	locationNames = map[string]bool{}
+	locationMutex sync.RWMutex
+)
+
+// match the datetime string with the unknown words, the words of the date are moved before the time if needed
+// the weekday is used only if there is no date, otherwise it's skipped if it's not the weekday of the date
+func matchFuzzyDateTime(value string, o *options) (FormatResult, []*Pattern, []string, error) {
+	var (
+		words, dates, times, skipped []string
+		weekday                      string
+	)
+	locale := o.locale
+	for _, word := range fuzzyWordRule.FindAllString(value, -1) {
+		cur := strings.TrimRight(strings.TrimLeft(word, "(\"'"), ")\"'!?")
+		// the full stop, but not the one of "p.m."
+		if strings.HasSuffix(cur, ".") && !fuzzyMeridianEndRule.MatchString(cur) {
+			cur = strings.TrimRight(cur, ".")
+		}
+		lower := strings.ToLower(cur)
+		switch {
+		case cur == "":
+			continue
+		case isWeekdayName(lower) || locale.weekdayNum(lower) >= 0:
+			weekday = cur
+			continue
+		case isMonthName(lower) || locale.monthNum(lower) > 0:
+			dates = append(dates, cur)
+		case fuzzyMeridianRule.MatchString(cur):
+			// the hour before the meridian, e.g. "6 pm"
+			if count := len(dates); count > 0 && len(words) > 0 && words[len(words)-1] == dates[count-1] {
+				times = append(times, dates[count-1])
+				dates = dates[:count-1]
+			}
+			times = append(times, cur)
+		case fuzzyNumberRule.MatchString(cur):
+			if fuzzyTimeRule.MatchString(cur) {
+				times = append(times, cur)
+			} else {
+				dates = append(dates, cur)
+			}
+		case fuzzyCJKDateRule.MatchString(cur):
+			dates = append(dates, cur)
+		case fuzzyZoneRule.MatchString(cur) && isLocationName(cur):
+			times = append(times, cur)
+		default:
+			skipped = append(skipped, word)
+			continue
+		}
+		words = append(words, cur)
+	}
+	if len(words) == 0 {
+		if weekday != "" {
+			return FormatResult{"l": weekday}, nil, skipped, nil
+		}
+		return nil, nil, nil, fmt.Errorf("wrong fuzzy datetime:'%s'", value)
+	}
+	// try the original order, then the date before the time, then skip one more word from the end
+	ordered := append(append([]string{}, dates...), times...)
+	candidates := [][]string{words, ordered}
+	for index := len(ordered) - 1; index >= 0; index-- {
+		candidates = append(candidates, append(append([]string{}, ordered[:index]...), ordered[index+1:]...))
+	}
+	for index, candidate := range candidates {
+		if len(candidate) == 0 {
+			continue
+		}
+		lasts, patterns, err := matchOptionsDateTime(strings.Join(candidate, " "), o)
+		if err != nil || isResultTimezone(lasts) {
+			continue
+		}
+		if index > 1 {
+			skipped = append(skipped, ordered[len(ordered)+1-index])
+		}
+		if weekday != "" {
+			if noEmptyField(lasts, "YY", "yy", "y", "MM", "mm", "M", "m", "hm", "DD", "dd", "l", "D", "Q", "HY") == "" {
+				lasts["l"] = weekday
+			} else if !isWeekdayOf(lasts, weekday, o) {
+				// the weekday conflicts with the date
+				skipped = append(skipped, weekday)
+			}
+		}
+		return lasts, patterns, skipped, nil
+	}
+	return nil, nil, nil, fmt.Errorf("wrong fuzzy datetime:'%s'", value)
+}
+
+// check if the word is a month name or a short name
+func isMonthName(word string) bool {
+	// the roman numbers are not included
+	for _, name := range allMonthExp[:25] {
+		if name == word {
+			return true
+		}
+	}
+	return false
+}
+
+// check if the word is a weekday name or a short name
+func isWeekdayName(word string) bool {
+	for index, name := range weekdayFullNames {
+		if strings.EqualFold(name, word) || strings.EqualFold(weekdayShortNames[index], word) {
+			return true
+		}
+	}
+	return false
+}
+
+// check if the weekday is the weekday of the date in the format result
+func isWeekdayOf(lasts FormatResult, weekday string, o *options) bool {
+	date, err := makeFormatDateTime(lasts, o)
+	if err != nil {
+		return false
+	}
+	num := o.locale.weekdayNum(weekday)
+	if num < 0 {
+		num = getWeekdayNum(weekday)
+	}
+	return int(date.Weekday()) == num
+}
+
+// check if the word is a timezone can be loaded, the results are cached since loading reads the zoneinfo files
+func isLocationName(word string) bool {
+	locationMutex.RLock()
+	ok, cached := locationNames[word]
+	locationMutex.RUnlock()
+	if cached {
+		return ok
+	}
+	_, err := time.LoadLocation(word)
+	locationMutex.Lock()
+	locationNames[word] = err == nil
+	locationMutex.Unlock()
+	return err == nil
+}
@@ -0,0 +1,86 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzy(t *testing.T) {
+	layout := "2006-01-02 15:04"
+	utc := WithLocation(time.UTC)
+	fuzzy := WithFuzzy()
+	cases := map[string]string{
+		"Meeting on Sunday, Sep 5th 2021 at 6pm please": "2021-09-05 18:00",
+		"at 6 pm on Sep 5th 2021":                       "2021-09-05 18:00",
+		"lunch at 12pm on 2021-09-05":                   "2021-09-05 12:00",
+		"at 12am on 2021-09-05":                         "2021-09-05 00:00",
+		"the deadline is 2021-09-05T10:00:00Z.":         "2021-09-05 10:00",
+		"order #12 shipped on 2021-09-05":               "2021-09-05 00:00",
+		"(5 September 2021) at 10:30 UTC":               "2021-09-05 10:30",
+		"Sept 5, 2021 at 10am Europe/Paris":             "2021-09-05 10:00",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc, fuzzy); err == nil {
+			assert.Equal(t, date.Format(layout), expect, value)
+		} else {
+			assert.Fail(t, "DateTime fuzzy '"+value+"' fail")
+		}
+	}
+	// the skipped words
+	if result, err := Parse("Meeting on Sunday, Sep 5th 2021 at 6pm please", utc, fuzzy); err == nil {
+		assert.Equal(t, result.Skipped, []string{"Meeting", "on", "at", "please"})
+		assert.Equal(t, result.Precision, PrecisionHour)
+	} else {
+		assert.Fail(t, "Parse fuzzy fail")
+	}
+	if result, err := Parse("order #12 shipped on 2021-09-05", utc, fuzzy); err == nil {
+		assert.Equal(t, result.Skipped, []string{"order", "#12", "shipped", "on"})
+	} else {
+		assert.Fail(t, "Parse fuzzy with number fail")
+	}
+	// the weekday conflicts with the date is skipped
+	if result, err := Parse("Meeting on Monday, Sep 5th 2021 at 6pm", utc, fuzzy); err == nil {
+		assert.Equal(t, result.Time.Format("2006-01-02"), "2021-09-05")
+		assert.Equal(t, result.Skipped, []string{"Meeting", "on", "at", "Monday"})
+	} else {
+		assert.Fail(t, "Parse fuzzy conflicted weekday fail")
+	}
+	// the calendar system
+	if date, err := DateTime("会议 110年9月5日 举行", utc, fuzzy, WithCalendarSystem(MinguoCalendar)); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-09-05")
+	} else {
+		assert.Fail(t, "DateTime fuzzy with calendar system fail")
+	}
+	// the weekday is used if there is no date
+	if result, err := Parse("see you on Monday at 10:30", utc, fuzzy); err == nil {
+		assert.Equal(t, result.Time.Weekday(), time.Monday)
+		assert.Equal(t, result.Time.Format("15:04"), "10:30")
+		assert.True(t, result.HasDate)
+		assert.Equal(t, result.Skipped, []string{"see", "you", "on", "at"})
+	} else {
+		assert.Fail(t, "Parse fuzzy weekday fail")
+	}
+	if result, err := Parse("see you on Friday", utc, fuzzy); err == nil {
+		assert.Equal(t, result.Time.Weekday(), time.Friday)
+		assert.Equal(t, result.Precision, PrecisionDay)
+	} else {
+		assert.Fail(t, "Parse fuzzy weekday only fail")
+	}
+	// no skipped words if the string can be parsed
+	if result, err := Parse("2021-09-05", utc, fuzzy); err == nil {
+		assert.Nil(t, result.Skipped)
+	} else {
+		assert.Fail(t, "Parse fuzzy without unknown words fail")
+	}
+	// not fuzzy
+	if _, err := DateTime("Meeting on Sep 5th 2021", utc); err == nil {
+		assert.Fail(t, "DateTime without fuzzy ok")
+	}
+	for _, value := range []string{"nothing here", "order #12", ""} {
+		if _, err := Parse(value, utc, fuzzy); err == nil {
+			assert.Fail(t, "Parse fuzzy wrong '"+value+"' ok")
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package dateutil
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// HijriCalendar the islamic calendar, the umm al-qura calendar of saudi arabia by default,
+// the dates out of the umm al-qura table from 1300 to 1600 AH use the arithmetic calendar
+type HijriCalendar struct {
+	// Tabular use the arithmetic (tabular) calendar, the leap years are the 2nd, 5th, 7th, 10th, 13th,
+	// 16th, 18th, 21st, 24th, 26th and 29th years of the 30 years cycle, the epoch is 16 July 622 (julian)
+	Tabular bool
+}
+
+var (
+	// UmmAlQuraCalendar the umm al-qura calendar, "27 Muharram 1443" is 2021-09-04
+	UmmAlQuraCalendar = HijriCalendar{}
+	// TabularHijriCalendar the arithmetic hijri calendar, "27 Muharram 1443" is 2021-09-05
+	TabularHijriCalendar = HijriCalendar{Tabular: true}
+	// HijriMonthNames the transliterated names of the hijri months
+	HijriMonthNames = [12]string{"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani", "Jumada al-Awwal", "Jumada al-Thani", "Rajab", "Shaban", "Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah"}
+	// HijriShortMonthNames the abbreviations of the transliterated names
+	HijriShortMonthNames = [12]string{"Muh.", "Saf.", "Rab. I", "Rab. II", "Jum. I", "Jum. II", "Raj.", "Sha.", "Ram.", "Shaw.", "Dhul-Q.", "Dhul-H."}
+	// HijriArabicMonthNames the arabic names of the hijri months
+	HijriArabicMonthNames = [12]string{"محرم", "صفر", "ربيع الأول", "ربيع الآخر", "جمادى الأولى", "جمادى الآخرة", "رجب", "شعبان", "رمضان", "شوال", "ذو القعدة", "ذو الحجة"}
+	// the other names can be parsed
+	hijriMonthVariants = [12][]string{
+		{"Muharam", "Moharram"},
+		{"Safer"},
+		{"Rabi I", "Rabi al-Awal", "Rabi' al-Awwal", "Rabiul Awal", "Rabi ul-Awwal", "ربيع الاول"},
+		{"Rabi II", "Rabi al-Akhir", "Rabi' al-Thani", "Rabi' al-Akhir", "Rabiul Akhir", "Rabi ul-Thani", "ربيع الثاني", "ربيع الاخر"},
+		{"Jumada I", "Jumada al-Ula", "Jumada al-Oola", "Jumadal Ula", "جمادى الاولى"},
+		{"Jumada II", "Jumada al-Akhirah", "Jumada al-Akhira", "Jumadal Akhirah", "جمادى الثانية", "جمادى الاخرة"},
+		nil,
+		{"Sha'ban", "Shaaban"},
+		{"Ramadhan", "Ramazan"},
+		{"Shawal"},
+		{"Dhul Qadah", "Dhu al-Qi'dah", "Dhul-Qa'dah", "Dhu'l-Qa'dah", "Dhul Qidah", "Zul Qadah", "ذي القعدة"},
+		{"Dhul Hijjah", "Dhu'l-Hijjah", "Dhul-Hijja", "Zul Hijjah", "ذي الحجة"},
+	}
+	// the days from 1970-01-01 to 1 Muharram 1 AH of the arithmetic calendar
+	hijriEpoch = daysFromUnix(time.Date(622, time.July, 19, 0, 0, 0, 0, time.UTC))
+	// the first year of the umm al-qura table and the days from 1970-01-01 to the first day of the year
+	ummAlQuraFirstYear = 1300
+	ummAlQuraEpoch     = daysFromUnix(time.Date(1882, time.November, 12, 0, 0, 0, 0, time.UTC))
+	// the months of the umm al-qura years from 1300 to 1600 AH, the bit of the month is set if it has 30 days
+	ummAlQuraMonths = []uint16{
+		0x555, 0x2ab, 0x937, 0x2b6, 0x576, 0x36c, 0xb55, 0xaaa, 0x956, 0x49e, 0x95d, 0x2ba,
+		0x5b5, 0x3aa, 0xb4b, 0xa96, 0x52e, 0x2ad, 0x56d, 0xb5a, 0x752, 0xf25, 0xe8a, 0xd16,
+		0xa56, 0xab5, 0x6b4, 0xda9, 0xb92, 0xb25, 0x64b, 0xa9b, 0x35a, 0x6d9, 0x5d4, 0xda5,
+		0xd4a, 0xa95, 0x536, 0x975, 0x2f4, 0x6e9, 0x6d4, 0x6a9, 0x535, 0x25d, 0x4bd, 0x9ba,
+		0x3b4, 0xb69, 0xb2a, 0xa55, 0x4ad, 0xa5d, 0x2da, 0x6d9, 0xeaa, 0xe94, 0xd2a, 0xc56,
+		0x4ae, 0xa6d, 0x56a, 0xd55, 0xd4a, 0xa93, 0x52b, 0xa5b, 0x53a, 0x6b5, 0xea9, 0xd52,
+		0xd29, 0xa55, 0x4ad, 0x56d, 0xaea, 0x6e4, 0xed1, 0xda2, 0xaaa, 0x95a, 0x2da, 0x5b9,
+		0xbb2, 0x764, 0x6c9, 0x555, 0x2ab, 0x4db, 0xaba, 0x5b4, 0xda9, 0xd52, 0xaa5, 0x92d,
+		0x26d, 0x8ed, 0x2da, 0xad5, 0xaa5, 0xa4b, 0x497, 0x937, 0x2b6, 0x975, 0xd69, 0xd52,
+		0xc95, 0x92b, 0x25b, 0x4db, 0x9d5, 0x5d2, 0xda5, 0xd4a, 0xa95, 0x54d, 0xaad, 0x3aa,
+		0xbd2, 0xbc4, 0xb89, 0xa95, 0x52d, 0x5ad, 0xb6a, 0x6d4, 0xdc9, 0xd92, 0xaa6, 0x956,
+		0x2ae, 0x56d, 0x36a, 0xb55, 0xaaa, 0x94d, 0x49d, 0x95d, 0x2ba, 0x5b5, 0x5aa, 0xd55,
+		0xa9a, 0x92e, 0x26e, 0x55d, 0xada, 0x6d4, 0x6a5, 0xb27, 0xa4d, 0x4ad, 0x56d, 0xb5a,
+		0x754, 0xf49, 0xe92, 0xd26, 0xa56, 0x356, 0x6b5, 0xbaa, 0xb92, 0xb25, 0x68b, 0xa9b,
+		0x55a, 0xada, 0x5b4, 0xda9, 0xb52, 0xa9a, 0x536, 0x276, 0x575, 0xaf2, 0x6d4, 0x6a9,
+		0x555, 0x2ad, 0x4bd, 0x9ba, 0x574, 0xb69, 0xb52, 0xa95, 0x52d, 0xa5d, 0x4da, 0xad9,
+		0x6b2, 0xe95, 0xe2a, 0xc96, 0x92e, 0xaad, 0x56a, 0xd65, 0xd4a, 0xd15, 0x62b, 0xc5b,
+		0x53a, 0x6b5, 0xdb2, 0xd64, 0xd29, 0xa55, 0x4ad, 0x96d, 0xaea, 0x6e8, 0xed1, 0xda4,
+		0xd4a, 0xa6a, 0x2da, 0x5b9, 0xb72, 0xb68, 0x6d1, 0x655, 0x4ab, 0x95b, 0x2ba, 0x5b5,
+		0xda9, 0xd52, 0xca6, 0x94e, 0x46e, 0x95d, 0x4da, 0xad5, 0xaaa, 0xa4d, 0x49b, 0x937,
+		0x4b6, 0x975, 0xd6a, 0xd52, 0xaa5, 0x94b, 0x2ab, 0x55b, 0xad9, 0x5d2, 0xdc5, 0xd92,
+		0xb25, 0x555, 0xab5, 0x5b4, 0xba9, 0x7a2, 0x745, 0x593, 0xaab, 0x4d6, 0x9d6, 0x5d2,
+		0xba5, 0xb4a, 0xa95, 0x4ad, 0x15d, 0x2dd, 0x9da, 0x5b4, 0x5a9, 0x52d, 0x25b, 0x8b7,
+		0x176, 0x56d, 0xb6a, 0xaca, 0xa96, 0x52b, 0x15b, 0x2bb, 0x5b6, 0xdaa, 0xb94, 0xd46,
+		0xa8d, 0x52d, 0xa9d, 0x55a, 0x755, 0x749, 0xf13, 0xe4a, 0xa96, 0x556, 0x6b5, 0xbaa,
+		0xb94,
+	}
+	// the days from 1970-01-01 to the first day of the umm al-qura years, the year after the last is included
+	ummAlQuraYearStarts []int
+	ummAlQuraOnce       sync.Once
+)
+
+// get the days from 1970-01-01 to the date of the time
+func daysFromUnix(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// get the first days of the umm al-qura years
+func getUmmAlQuraYearStarts() []int {
+	ummAlQuraOnce.Do(func() {
+		days := ummAlQuraEpoch
+		ummAlQuraYearStarts = make([]int, 0, len(ummAlQuraMonths)+1)
+		for _, months := range ummAlQuraMonths {
+			ummAlQuraYearStarts = append(ummAlQuraYearStarts, days)
+			days += 29 * 12
+			for month := 0; month < 12; month++ {
+				days += int(months>>uint(month)) & 1
+			}
+		}
+		ummAlQuraYearStarts = append(ummAlQuraYearStarts, days)
+	})
+	return ummAlQuraYearStarts
+}
+
+// check if the year is in the umm al-qura table
+func (calendar HijriCalendar) isUmmAlQura(year int) bool {
+	return !calendar.Tabular && year >= ummAlQuraFirstYear && year < ummAlQuraFirstYear+len(ummAlQuraMonths)
+}
+
+// get the days from 1970-01-01 to the first day of the month, the month is from 1 to 12
+func (calendar HijriCalendar) monthStart(year, month int) int {
+	if calendar.isUmmAlQura(year) {
+		days := getUmmAlQuraYearStarts()[year-ummAlQuraFirstYear]
+		for cur := 1; cur < month; cur++ {
+			days += calendar.DaysInMonth(year, cur)
+		}
+		return days
+	}
+	return hijriEpoch + 354*(year-1) + floorDiv(3+11*year, 30) + (59*(month-1)+1)/2
+}
+
+// FromGregorian get the hijri year, month and day of the time
+func (calendar HijriCalendar) FromGregorian(t time.Time) (int, int, int) {
+	days := daysFromUnix(t)
+	var year int
+	if starts := getUmmAlQuraYearStarts(); !calendar.Tabular && days >= starts[0] && days < starts[len(starts)-1] {
+		year = ummAlQuraFirstYear
+		for days >= starts[year-ummAlQuraFirstYear+1] {
+			year++
+		}
+	} else {
+		// the arithmetic calendar
+		calendar = TabularHijriCalendar
+		year = floorDiv(30*(days-hijriEpoch)+10646, 10631)
+		for calendar.monthStart(year+1, 1) <= days {
+			year++
+		}
+		for calendar.monthStart(year, 1) > days {
+			year--
+		}
+	}
+	month := 1
+	for month < 12 && calendar.monthStart(year, month+1) <= days {
+		month++
+	}
+	return year, month, days - calendar.monthStart(year, month) + 1
+}
+
+// ToGregorian get the gregorian year, month and day of the hijri date
+func (calendar HijriCalendar) ToGregorian(year, month, day int) (int, int, int) {
+	// the months out of the year
+	year += floorDiv(month-1, 12)
+	month = floorMod(month-1, 12) + 1
+	days := calendar.monthStart(year, month) + day - 1
+	gregorianYear, gregorianMonth, gregorianDay := time.Unix(int64(days)*86400, 0).UTC().Date()
+	return gregorianYear, int(gregorianMonth), gregorianDay
+}
+
+// DaysInMonth get the days of the hijri month, 29 or 30
+func (calendar HijriCalendar) DaysInMonth(year, month int) int {
+	if month < 1 || month > 12 {
+		return 0
+	}
+	if calendar.isUmmAlQura(year) {
+		return 29 + int(ummAlQuraMonths[year-ummAlQuraFirstYear]>>uint(month-1))&1
+	}
+	// the last month of the leap years has 30 days
+	if month%2 == 1 || (month == 12 && floorMod(14+11*year, 30) < 11) {
+		return 30
+	}
+	return 29
+}
+
+// get the name of the hijri month, the arabic names are used with the arabic locale
+func (calendar HijriCalendar) monthName(month int, short bool, locale *Locale) string {
+	if locale != nil && strings.EqualFold(locale.Name, "ar") {
+		return HijriArabicMonthNames[month-1]
+	}
+	if short {
+		return HijriShortMonthNames[month-1]
+	}
+	return HijriMonthNames[month-1]
+}
+
+// get the hijri month from 1 to 12 by the name, 0 if not found
+func hijriMonthNum(name string) int {
+	name = foldHijriName(name)
+	for index, names := range hijriMonthNames() {
+		for _, cur := range names {
+			if foldHijriName(cur) == name {
+				return index + 1
+			}
+		}
+	}
+	return 0
+}
+
+// get all the names of the hijri months
+func hijriMonthNames() [12][]string {
+	var names [12][]string
+	for index := range names {
+		names[index] = append([]string{HijriMonthNames[index], HijriShortMonthNames[index], HijriArabicMonthNames[index]}, hijriMonthVariants[index]...)
+	}
+	return names
+}
+
+// lowercase the name and remove the apostrophes, hyphens and spaces, e.g. "Dhu'l-Hijjah" is "dhulhijjah"
+func foldHijriName(name string) string {
+	return hijriNameReplacer.Replace(strings.ToLower(strings.TrimSuffix(name, ".")))
+}
+
+var hijriNameReplacer = strings.NewReplacer("'", "", "’", "", "ʻ", "", "-", "", " ", "", ".", "")
+
+// the expression of the hijri month names for the date rules
+func hijriMonthExp() string {
+	names := hijriMonthNames()
+	return "(" + strings.Join(flattenNames(names[:]), "|") + ")"
+}
@@ -0,0 +1,96 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHijriCalendar(t *testing.T) {
+	cases := []struct {
+		calendar HijriCalendar
+		date     time.Time
+		expect   []int
+	}{
+		{UmmAlQuraCalendar, time.Date(1882, time.November, 12, 0, 0, 0, 0, time.UTC), []int{1300, 1, 1}},
+		{UmmAlQuraCalendar, time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), []int{1420, 9, 24}},
+		{UmmAlQuraCalendar, time.Date(2021, time.September, 5, 23, 0, 0, 0, time.UTC), []int{1443, 1, 28}},
+		{UmmAlQuraCalendar, time.Date(2077, time.November, 16, 0, 0, 0, 0, time.UTC), []int{1500, 12, 30}},
+		{TabularHijriCalendar, time.Date(622, time.July, 19, 0, 0, 0, 0, time.UTC), []int{1, 1, 1}},
+		{TabularHijriCalendar, time.Date(2021, time.September, 5, 0, 0, 0, 0, time.UTC), []int{1443, 1, 27}},
+		{TabularHijriCalendar, time.Date(2077, time.November, 16, 0, 0, 0, 0, time.UTC), []int{1500, 12, 29}},
+		// out of the umm al-qura table
+		{UmmAlQuraCalendar, time.Date(2300, time.January, 1, 0, 0, 0, 0, time.UTC), []int{1729, 12, 8}},
+	}
+	for _, item := range cases {
+		year, month, day := item.calendar.FromGregorian(item.date)
+		assert.Equal(t, []int{year, month, day}, item.expect, item.date.String())
+		year, month, day = item.calendar.ToGregorian(item.expect[0], item.expect[1], item.expect[2])
+		assert.Equal(t, []int{year, month, day}, []int{item.date.Year(), int(item.date.Month()), item.date.Day()})
+	}
+	// the months out of the year
+	year, month, day := UmmAlQuraCalendar.ToGregorian(1442, 13, 1)
+	assert.Equal(t, []int{year, month, day}, []int{2021, 8, 9})
+	assert.Equal(t, UmmAlQuraCalendar.DaysInMonth(1443, 1), 30)
+	assert.Equal(t, UmmAlQuraCalendar.DaysInMonth(1443, 2), 29)
+	assert.Equal(t, TabularHijriCalendar.DaysInMonth(1442, 12), 30)
+	assert.Equal(t, TabularHijriCalendar.DaysInMonth(1443, 12), 29)
+}
+
+func TestHijriParse(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]string{
+		"27 Muharram 1443":           "2021-09-04 00:00",
+		"27 muharram 1443 AH":        "2021-09-04 00:00",
+		"Muharram 27, 1443":          "2021-09-04 00:00",
+		"٢٧ محرم ١٤٤٣ هـ":            "2021-09-04 00:00",
+		"27 Rabi al-Awwal 1443":      "2021-11-02 00:00",
+		"10 Dhu'l-Hijjah 1442 18:00": "2021-07-20 18:00",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc); err == nil {
+			assert.Equal(t, date.Format("2006-01-02 15:04"), expect, value)
+		} else {
+			assert.Fail(t, "DateTime '"+value+"' fail")
+		}
+	}
+	// the calendar option
+	tabular := WithCalendarSystem(TabularHijriCalendar)
+	for value, expect := range map[string]string{"27 Muharram 1443": "2021-09-05", "1443-01-27": "2021-09-05"} {
+		if date, err := DateTime(value, utc, tabular); err == nil {
+			assert.Equal(t, date.Format("2006-01-02"), expect, value)
+		} else {
+			assert.Fail(t, "DateTime tabular '"+value+"' fail")
+		}
+	}
+	if result, err := Parse("Ramadan 1443", utc); err == nil {
+		year, month, _ := UmmAlQuraCalendar.FromGregorian(result.Time)
+		assert.Equal(t, []int{year, month}, []int{1443, 9})
+		assert.Equal(t, result.Precision, PrecisionMonth)
+	} else {
+		assert.Fail(t, "Parse hijri month fail")
+	}
+}
+
+func TestHijriFormat(t *testing.T) {
+	date := time.Date(2021, time.September, 5, 18, 7, 6, 0, time.UTC)
+	ummAlQura := WithCalendarSystem(UmmAlQuraCalendar)
+	cases := map[string]string{
+		"j F Y":   "28 Muharram 1443",
+		"d/m/Y t": "28/01/1443 30",
+		"j M y":   "28 Muh. 43",
+	}
+	for format, expect := range cases {
+		if value, err := DateFormat(date, format, ummAlQura); err == nil {
+			assert.Equal(t, value, expect, format)
+		} else {
+			assert.Fail(t, "DateFormat hijri '"+format+"' fail")
+		}
+	}
+	if value, err := DateFormatLocale(date, "l j F Y", "ar", ummAlQura); err == nil {
+		assert.Equal(t, value, "الأحد 28 محرم 1443")
+	} else {
+		assert.Fail(t, "DateFormatLocale hijri fail")
+	}
+}
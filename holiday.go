@@ -0,0 +1,283 @@
+package dateutil
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ObservedMode how to shift the holiday when it falls on saturday or sunday
+type ObservedMode int
+
+const (
+	// ObservedNone don't shift the holiday
+	ObservedNone ObservedMode = iota
+	// ObservedNearest saturday is observed on friday and sunday is observed on monday, e.g. the US federal holidays
+	ObservedNearest
+	// ObservedNextWeekday observed on the next weekday which is not another holiday, e.g. the UK substitute days
+	ObservedNextWeekday
+)
+
+// Holiday a named holiday rule
+type Holiday struct {
+	Name     string
+	Rule     HolidayRule
+	Observed ObservedMode
+	// From the first year of the holiday, 0 means all the years
+	From int
+}
+
+// HolidayDate the holiday in a year
+type HolidayDate struct {
+	Name string
+	// the date of the holiday at midnight in UTC
+	Date time.Time
+	// the observed date, the same as the date if not shifted
+	Observed time.Time
+}
+
+var (
+	holidayMonthExp = "(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*"
+	// "12-25"
+	holidayFixedRule = regexp.MustCompile("^([0-9]{1,2})-([0-9]{1,2})$")
+	// "december 25", "dec 25"
+	holidayMonthDayRule = regexp.MustCompile("(?i)^" + holidayMonthExp + "[ \\t]+([0-9]{1,2})$")
+	// "25 december"
+	holidayDayMonthRule = regexp.MustCompile("(?i)^([0-9]{1,2})[ \\t]+" + holidayMonthExp + "$")
+	// "third monday of january", "last monday in may"
+	holidayNthWeekdayRule = regexp.MustCompile("(?i)^(first|1st|second|2nd|third|3rd|fourth|4th|fifth|5th|last)[ \\t]+" + relativeWeekdayExp + "[ \\t]+(?:of|in)[ \\t]+" + holidayMonthExp + "$")
+	// "easter", "easter -2", "orthodox easter +1"
+	holidayEasterRule = regexp.MustCompile("(?i)^(orthodox[ \\t]+)?easter(?:[ \\t]*([+-])[ \\t]*([0-9]+))?$")
+	// the options at the end of a holiday line, "observed", "substitute", "from 2021"
+	holidayOptionsRule = regexp.MustCompile("(?i)^(.+?)(?:[ \\t]+(observed|substitute))?(?:[ \\t]+from[ \\t]+([0-9]{4}))?$")
+	holidayOrdinals    = map[string]int{
+		"first": 1, "1st": 1,
+		"second": 2, "2nd": 2,
+		"third": 3, "3rd": 3,
+		"fourth": 4, "4th": 4,
+		"fifth": 5, "5th": 5,
+		"last": -1,
+	}
+)
+
+// OrthodoxEaster get the orthodox easter sunday of the year in the gregorian calendar at midnight in UTC
+func OrthodoxEaster(year int) time.Time {
+	// the meeus julian algorithm
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	// the days between the julian and gregorian calendars
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, year/100-year/400-2)
+}
+
+// get the month by the name or the short name
+func getMonthNum(name string) time.Month {
+	for index, short := range monthShortNames {
+		if strings.EqualFold(short, name[0:3]) {
+			return time.Month(index + 1)
+		}
+	}
+	return 0
+}
+
+// ParseHolidayRule parse the holiday rule
+// the fixed dates "12-25", "december 25", "25 dec"
+// the nth weekday of the month "third monday of january", "last monday in may"
+// the days relative to the easter "easter -2", "orthodox easter +1"
+func ParseHolidayRule(expr string) (HolidayRule, error) {
+	expr = strings.TrimSpace(expr)
+	var (
+		month time.Month
+		day   int
+	)
+	if matchs := holidayFixedRule.FindStringSubmatch(expr); matchs != nil {
+		num, _ := strconv.Atoi(matchs[1])
+		month = time.Month(num)
+		day, _ = strconv.Atoi(matchs[2])
+	} else if matchs := holidayMonthDayRule.FindStringSubmatch(expr); matchs != nil {
+		month = getMonthNum(matchs[1])
+		day, _ = strconv.Atoi(matchs[2])
+	} else if matchs := holidayDayMonthRule.FindStringSubmatch(expr); matchs != nil {
+		month = getMonthNum(matchs[2])
+		day, _ = strconv.Atoi(matchs[1])
+	} else if matchs := holidayNthWeekdayRule.FindStringSubmatch(expr); matchs != nil {
+		return NthWeekdayHoliday{
+			Month:   getMonthNum(matchs[3]),
+			Weekday: time.Weekday(getWeekdayNum(matchs[2])),
+			N:       holidayOrdinals[strings.ToLower(matchs[1])],
+		}, nil
+	} else if matchs := holidayEasterRule.FindStringSubmatch(expr); matchs != nil {
+		offset, _ := strconv.Atoi(matchs[3])
+		if matchs[2] == "-" {
+			offset = -offset
+		}
+		return EasterHoliday{
+			Offset:   offset,
+			Orthodox: matchs[1] != "",
+		}, nil
+	} else {
+		return nil, fmt.Errorf("wrong holiday rule:'%s'", expr)
+	}
+	// the fixed date, allow "02-29"
+	if month < time.January || month > time.December || day < 1 || day > daysInMonth(2000, month) {
+		return nil, fmt.Errorf("wrong holiday date:'%s'", expr)
+	}
+	return FixedHoliday{month, day}, nil
+}
+
+// ParseHolidays parse the holidays, one holiday a line, e.g. "Christmas Day: 12-25 observed"
+// the format is "name: rule [observed|substitute] [from year]", the empty lines and the lines start with '#' are ignored
+// 'observed' is 'ObservedNearest', 'substitute' is 'ObservedNextWeekday'
+func ParseHolidays(reader io.Reader) ([]Holiday, error) {
+	var holidays []Holiday
+	scanner := bufio.NewScanner(reader)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		colon := strings.LastIndex(line, ":")
+		if colon <= 0 {
+			return nil, fmt.Errorf("wrong holiday at line %d:'%s'", lineNo, line)
+		}
+		holiday := Holiday{
+			Name: strings.TrimSpace(line[:colon]),
+		}
+		matchs := holidayOptionsRule.FindStringSubmatch(strings.TrimSpace(line[colon+1:]))
+		if matchs == nil {
+			return nil, fmt.Errorf("wrong holiday at line %d:'%s'", lineNo, line)
+		}
+		switch strings.ToLower(matchs[2]) {
+		case "observed":
+			holiday.Observed = ObservedNearest
+		case "substitute":
+			holiday.Observed = ObservedNextWeekday
+		}
+		holiday.From, _ = strconv.Atoi(matchs[3])
+		rule, err := ParseHolidayRule(matchs[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s at line %d", err.Error(), lineNo)
+		}
+		holiday.Rule = rule
+		holidays = append(holidays, holiday)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// LoadHolidayCalendar make a holiday calendar by the holiday rules, the format is the same as 'ParseHolidays'
+func LoadHolidayCalendar(reader io.Reader) (*HolidayCalendar, error) {
+	holidays, err := ParseHolidays(reader)
+	if err != nil {
+		return nil, err
+	}
+	calendar, _ := NewHolidayCalendar()
+	return calendar.AddHolidays(holidays...), nil
+}
+
+// the shipped holiday files, the format is the same as 'ParseHolidays'
+//
+//go:embed holidays/*.txt
+var holidayFiles embed.FS
+
+// load the calendar of a shipped holiday file, the files are checked by the tests
+func loadHolidayFile(name string) *HolidayCalendar {
+	data, _ := holidayFiles.ReadFile("holidays/" + name)
+	calendar, _ := LoadHolidayCalendar(bytes.NewReader(data))
+	return calendar
+}
+
+// USFederalHolidays make a new holiday calendar of the united states federal holidays, loaded from "holidays/us_federal.txt"
+func USFederalHolidays() *HolidayCalendar {
+	return loadHolidayFile("us_federal.txt")
+}
+
+// UKBankHolidays make a new holiday calendar of the bank holidays of england and wales, loaded from "holidays/uk_bank.txt"
+func UKBankHolidays() *HolidayCalendar {
+	return loadHolidayFile("uk_bank.txt")
+}
+
+// AddHolidays add the named holidays to the calendar
+func (calendar *HolidayCalendar) AddHolidays(holidays ...Holiday) *HolidayCalendar {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+	calendar.holidays = append(calendar.holidays, holidays...)
+	// evaluate the years again
+	calendar.years = map[int]map[[3]int]bool{}
+	return calendar
+}
+
+// Holidays get the holidays of the rules in the year ordered by the date, the static holidays are not included
+func (calendar *HolidayCalendar) Holidays(year int) []HolidayDate {
+	calendar.mutex.Lock()
+	defer calendar.mutex.Unlock()
+	return calendar.holidaysOf(year)
+}
+
+// evaluate the holidays of the year and shift the observed days
+func (calendar *HolidayCalendar) holidaysOf(year int) []HolidayDate {
+	var (
+		result []HolidayDate
+		modes  []ObservedMode
+	)
+	taken := map[[3]int]bool{}
+	for _, holiday := range calendar.holidays {
+		if year < holiday.From {
+			continue
+		}
+		if date, ok := holiday.Rule.Holiday(year); ok {
+			result = append(result, HolidayDate{
+				Name: holiday.Name,
+				Date: date,
+			})
+			modes = append(modes, holiday.Observed)
+			taken[dateKey(date)] = true
+		}
+	}
+	indexes := make([]int, len(result))
+	for index := range indexes {
+		indexes[index] = index
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return result[indexes[i]].Date.Before(result[indexes[j]].Date)
+	})
+	sorted := make([]HolidayDate, len(result))
+	for order, index := range indexes {
+		cur := result[index]
+		observed := cur.Date
+		weekday := observed.Weekday()
+		switch modes[index] {
+		case ObservedNearest:
+			if weekday == time.Saturday {
+				observed = observed.AddDate(0, 0, -1)
+			} else if weekday == time.Sunday {
+				observed = observed.AddDate(0, 0, 1)
+			}
+		case ObservedNextWeekday:
+			if weekday == time.Saturday || weekday == time.Sunday {
+				for {
+					observed = observed.AddDate(0, 0, 1)
+					weekday = observed.Weekday()
+					if weekday != time.Saturday && weekday != time.Sunday && !taken[dateKey(observed)] {
+						break
+					}
+				}
+			}
+		}
+		taken[dateKey(observed)] = true
+		cur.Observed = observed
+		sorted[order] = cur
+	}
+	return sorted
+}
@@ -0,0 +1,125 @@
+package dateutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// format the holidays as "name=date/observed"
+func formatHolidays(holidays []HolidayDate) []string {
+	result := make([]string, len(holidays))
+	for index, holiday := range holidays {
+		result[index] = holiday.Name + "=" + holiday.Date.Format("01-02") + "/" + holiday.Observed.Format("01-02")
+	}
+	return result
+}
+
+func TestOrthodoxEaster(t *testing.T) {
+	for year, expect := range map[int]string{
+		2008: "2008-04-27",
+		2021: "2021-05-02",
+		2022: "2022-04-24",
+		2023: "2023-04-16",
+		2024: "2024-05-05",
+	} {
+		assert.Equal(t, OrthodoxEaster(year).Format("2006-01-02"), expect)
+	}
+}
+
+func TestParseHolidayRule(t *testing.T) {
+	cases := map[string]HolidayRule{
+		"12-25":                    FixedHoliday{time.December, 25},
+		"December 25":              FixedHoliday{time.December, 25},
+		"1 jan":                    FixedHoliday{time.January, 1},
+		"02-29":                    FixedHoliday{time.February, 29},
+		"third Monday of January":  NthWeekdayHoliday{time.January, time.Monday, 3},
+		"last mon in may":          NthWeekdayHoliday{time.May, time.Monday, -1},
+		"5th friday of march":      NthWeekdayHoliday{time.March, time.Friday, 5},
+		"easter":                   EasterHoliday{},
+		"Easter -2":                EasterHoliday{Offset: -2},
+		"easter+49":                EasterHoliday{Offset: 49},
+		"orthodox easter + 1":      EasterHoliday{Offset: 1, Orthodox: true},
+		"  orthodox   easter  -48": EasterHoliday{Offset: -48, Orthodox: true},
+	}
+	for expr, expect := range cases {
+		if rule, err := ParseHolidayRule(expr); err == nil {
+			assert.Equal(t, rule, expect)
+		} else {
+			assert.Fail(t, "ParseHolidayRule '"+expr+"' fail")
+		}
+	}
+	for _, expr := range []string{"", "13-01", "02-30", "sixth monday of may", "easter 2", "christmas"} {
+		if _, err := ParseHolidayRule(expr); err == nil {
+			assert.Fail(t, "ParseHolidayRule wrong rule '"+expr+"' ok")
+		}
+	}
+}
+
+func TestHolidayCalendar(t *testing.T) {
+	// the shipped calendars are loaded from the holiday files
+	files, _ := filepath.Glob(filepath.Join("holidays", "*.txt"))
+	assert.Equal(t, len(files), 2)
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			assert.Fail(t, "open the holiday file '"+name+"' fail")
+			continue
+		}
+		if calendar, err := LoadHolidayCalendar(file); err == nil {
+			assert.True(t, len(calendar.Holidays(2021)) > 0, name)
+		} else {
+			assert.Fail(t, "LoadHolidayCalendar '"+name+"' fail:"+err.Error())
+		}
+		file.Close()
+	}
+	us := USFederalHolidays()
+	assert.Equal(t, formatHolidays(us.Holidays(2021)), []string{
+		"New Year's Day=01-01/01-01",
+		"Birthday of Martin Luther King, Jr.=01-18/01-18",
+		"Washington's Birthday=02-15/02-15",
+		"Memorial Day=05-31/05-31",
+		"Juneteenth National Independence Day=06-19/06-18",
+		"Independence Day=07-04/07-05",
+		"Labor Day=09-06/09-06",
+		"Columbus Day=10-11/10-11",
+		"Veterans Day=11-11/11-11",
+		"Thanksgiving Day=11-25/11-25",
+		"Christmas Day=12-25/12-24",
+	})
+	assert.Equal(t, len(us.Holidays(2020)), 10)
+	// the new year's day of 2022 is observed in 2021
+	assert.True(t, us.IsHoliday(time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, us.IsBusinessDay(time.Date(2021, time.July, 5, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, AddBusinessDays(time.Date(2021, time.December, 23, 0, 0, 0, 0, time.UTC), 1, us).Format("2006-01-02"), "2021-12-27")
+	// the substitute days
+	uk := UKBankHolidays()
+	assert.Equal(t, formatHolidays(uk.Holidays(2021))[6:], []string{"Christmas Day=12-25/12-27", "Boxing Day=12-26/12-28"})
+	assert.Equal(t, formatHolidays(uk.Holidays(2022)), []string{
+		"New Year's Day=01-01/01-03",
+		"Good Friday=04-15/04-15",
+		"Easter Monday=04-18/04-18",
+		"Early May bank holiday=05-02/05-02",
+		"Spring bank holiday=05-30/05-30",
+		"Summer bank holiday=08-29/08-29",
+		"Christmas Day=12-25/12-27",
+		"Boxing Day=12-26/12-26",
+	})
+	if date, err := DateTime("2022-12-23 +1 weekday", WithLocation(time.UTC), WithBusinessCalendar(uk)); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2022-12-28")
+	} else {
+		assert.Fail(t, "DateTime +1 weekday with uk calendar fail")
+	}
+	// the wrong holidays
+	for _, text := range []string{"no colon", ": 12-25", "Holiday: 12-32", "Holiday: 12-25 observed from"} {
+		if _, err := ParseHolidays(strings.NewReader("# comment\n\n" + text)); err == nil {
+			assert.Fail(t, "ParseHolidays wrong holiday '"+text+"' ok")
+		} else {
+			assert.True(t, strings.Contains(err.Error(), "line 3"))
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package dateutil
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// HumanizeStyle the style of the humanized string
+type HumanizeStyle int
+
+const (
+	// HumanizeLong e.g. "3 minutes ago", "in 2 days"
+	HumanizeLong HumanizeStyle = iota
+	// HumanizeShort e.g. "3m ago", "in 2d"
+	HumanizeShort
+	// HumanizeCalendar e.g. "Yesterday at 17:07", "Last Monday"
+	HumanizeCalendar
+)
+
+// HumanizeThresholds the max value of a unit, when reached, the next unit will be used
+// the zero fields will use the default values, the same as moment.js
+type HumanizeThresholds struct {
+	// less than the seconds will be "a few seconds", default 45
+	Seconds int
+	// default 45 minutes
+	Minutes int
+	// default 22 hours
+	Hours int
+	// default 26 days
+	Days int
+	// if set, the days more than 7 will use weeks until the threshold, default 0 means no weeks
+	Weeks int
+	// default 11 months
+	Months int
+}
+
+// HumanizeCalendarFormats the php date formats of the calendar style, escape the literal with '\'
+type HumanizeCalendarFormats struct {
+	SameDay  string
+	NextDay  string
+	NextWeek string
+	LastDay  string
+	LastWeek string
+	SameElse string
+}
+
+// HumanizeOptions the options of 'Humanize'
+type HumanizeOptions struct {
+	Style      HumanizeStyle
+	Thresholds HumanizeThresholds
+	// the past and future phrases, '%s' is the relative time
+	// default "%s ago" and "in %s"
+	Past   string
+	Future string
+	// the formats of the calendar style
+	Calendar HumanizeCalendarFormats
+}
+
+var (
+	defaultHumanizeThresholds = HumanizeThresholds{
+		Seconds: 45,
+		Minutes: 45,
+		Hours:   22,
+		Days:    26,
+		Months:  11,
+	}
+	defaultHumanizeCalendar = HumanizeCalendarFormats{
+		SameDay:  "\\T\\o\\d\\a\\y \\a\\t H:i",
+		NextDay:  "\\T\\o\\m\\o\\r\\r\\o\\w \\a\\t H:i",
+		NextWeek: "\\N\\e\\x\\t l",
+		LastDay:  "\\Y\\e\\s\\t\\e\\r\\d\\a\\y \\a\\t H:i",
+		LastWeek: "\\L\\a\\s\\t l",
+		SameElse: "Y-m-d",
+	}
+	// the unit names of the long style and the short style
+	humanizeUnits = map[string][3]string{
+		"second": {"a few seconds", "seconds", "s"},
+		"minute": {"a minute", "minutes", "m"},
+		"hour":   {"an hour", "hours", "h"},
+		"day":    {"a day", "days", "d"},
+		"week":   {"a week", "weeks", "w"},
+		"month":  {"a month", "months", "mo"},
+		"year":   {"a year", "years", "y"},
+	}
+)
+
+// fill the zero fields with the default values
+func (opts HumanizeOptions) withDefaults() HumanizeOptions {
+	thresholds := &opts.Thresholds
+	defaults := defaultHumanizeThresholds
+	for _, cur := range [][2]*int{
+		{&thresholds.Seconds, &defaults.Seconds},
+		{&thresholds.Minutes, &defaults.Minutes},
+		{&thresholds.Hours, &defaults.Hours},
+		{&thresholds.Days, &defaults.Days},
+		{&thresholds.Months, &defaults.Months},
+	} {
+		if *cur[0] <= 0 {
+			*cur[0] = *cur[1]
+		}
+	}
+	if opts.Past == "" {
+		opts.Past = "%s ago"
+	}
+	if opts.Future == "" {
+		opts.Future = "in %s"
+	}
+	calendar := &opts.Calendar
+	defaultCalendar := defaultHumanizeCalendar
+	for _, cur := range [][2]*string{
+		{&calendar.SameDay, &defaultCalendar.SameDay},
+		{&calendar.NextDay, &defaultCalendar.NextDay},
+		{&calendar.NextWeek, &defaultCalendar.NextWeek},
+		{&calendar.LastDay, &defaultCalendar.LastDay},
+		{&calendar.LastWeek, &defaultCalendar.LastWeek},
+		{&calendar.SameElse, &defaultCalendar.SameElse},
+	} {
+		if *cur[0] == "" {
+			*cur[0] = *cur[1]
+		}
+	}
+	return opts
+}
+
+// get the relative unit and the count of the duration
+func humanizeUnit(duration time.Duration, thresholds HumanizeThresholds) (string, int) {
+	round := func(value float64) int {
+		return int(math.Round(value))
+	}
+	seconds := round(duration.Seconds())
+	minutes := round(duration.Minutes())
+	hours := round(duration.Hours())
+	days := round(duration.Hours() / 24)
+	weeks := round(duration.Hours() / 24 / 7)
+	// the average days of a month in the gregorian calendar
+	months := round(duration.Hours() / 24 / (146097.0 / 4800))
+	years := round(duration.Hours() / 24 / (146097.0 / 400))
+	switch {
+	case seconds < thresholds.Seconds:
+		return "second", seconds
+	case minutes < thresholds.Minutes:
+		return "minute", minutes
+	case hours < thresholds.Hours:
+		return "hour", hours
+	case days < 7 || (days < thresholds.Days && thresholds.Weeks <= 0):
+		return "day", days
+	case thresholds.Weeks > 0 && weeks < thresholds.Weeks:
+		return "week", weeks
+	case months < thresholds.Months:
+		return "month", months
+	}
+	return "year", years
+}
+
+// Humanize get the relative time string of the time 't' to the 'base' time
+// e.g. "3 minutes ago", "in 2 days", "3m ago", "Yesterday at 17:07"
+// 'opts' can be nil for the default options
+func Humanize(t, base time.Time, opts *HumanizeOptions) string {
+	if opts == nil {
+		opts = &HumanizeOptions{}
+	}
+	options := opts.withDefaults()
+	if options.Style == HumanizeCalendar {
+		return humanizeCalendar(t, base, options.Calendar)
+	}
+	duration := t.Sub(base)
+	isFuture := duration > 0
+	if duration < 0 {
+		duration = -duration
+	}
+	unit, count := humanizeUnit(duration, options.Thresholds)
+	names := humanizeUnits[unit]
+	var relative string
+	if options.Style == HumanizeShort {
+		if count == 0 {
+			return "now"
+		}
+		relative = fmt.Sprintf("%d%s", count, names[2])
+	} else {
+		if count == 0 {
+			return "just now"
+		}
+		if count == 1 || unit == "second" {
+			relative = names[0]
+		} else {
+			relative = fmt.Sprintf("%d %s", count, names[1])
+		}
+	}
+	if isFuture {
+		return fmt.Sprintf(options.Future, relative)
+	}
+	return fmt.Sprintf(options.Past, relative)
+}
+
+// the calendar style, compare the days in the location of the base time
+func humanizeCalendar(t, base time.Time, formats HumanizeCalendarFormats) string {
+	t = t.In(base.Location())
+	days := daysBetween(base, t)
+	var format string
+	switch {
+	case days == 0:
+		format = formats.SameDay
+	case days == 1:
+		format = formats.NextDay
+	case days == -1:
+		format = formats.LastDay
+	case days > 1 && days < 7:
+		format = formats.NextWeek
+	case days < -1 && days > -7:
+		format = formats.LastWeek
+	default:
+		format = formats.SameElse
+	}
+	result, _ := DateFormat(t, format)
+	return result
+}
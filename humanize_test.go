@@ -0,0 +1,51 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	base := makeTestTime()
+	// long style
+	assert.Equal(t, Humanize(base, base, nil), "just now")
+	assert.Equal(t, Humanize(base.Add(-10*time.Second), base, nil), "a few seconds ago")
+	assert.Equal(t, Humanize(base.Add(-3*time.Minute), base, nil), "3 minutes ago")
+	assert.Equal(t, Humanize(base.Add(-50*time.Minute), base, nil), "an hour ago")
+	assert.Equal(t, Humanize(base.Add(5*time.Hour), base, nil), "in 5 hours")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, 2), base, nil), "in 2 days")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, -20), base, nil), "20 days ago")
+	assert.Equal(t, Humanize(base.AddDate(0, -3, 0), base, nil), "3 months ago")
+	assert.Equal(t, Humanize(base.AddDate(2, 0, 0), base, nil), "in 2 years")
+	// short style
+	short := &HumanizeOptions{
+		Style: HumanizeShort,
+	}
+	assert.Equal(t, Humanize(base, base, short), "now")
+	assert.Equal(t, Humanize(base.Add(-3*time.Minute), base, short), "3m ago")
+	assert.Equal(t, Humanize(base.Add(10*time.Second), base, short), "in 10s")
+	assert.Equal(t, Humanize(base.AddDate(0, -3, 0), base, short), "3mo ago")
+	// custom phrases and thresholds
+	custom := &HumanizeOptions{
+		Style: HumanizeShort,
+		Past:  "%s",
+		Thresholds: HumanizeThresholds{
+			Minutes: 90,
+			Weeks:   4,
+		},
+	}
+	assert.Equal(t, Humanize(base.Add(-80*time.Minute), base, custom), "80m")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, -14), base, custom), "2w")
+	// calendar style
+	calendar := &HumanizeOptions{
+		Style: HumanizeCalendar,
+	}
+	assert.Equal(t, Humanize(base.Add(-time.Hour), base, calendar), "Today at 17:07")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, -1).Add(-time.Hour), base, calendar), "Yesterday at 17:07")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, 1), base, calendar), "Tomorrow at 18:07")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, -6), base, calendar), "Last Monday")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, 3), base, calendar), "Next Wednesday")
+	assert.Equal(t, Humanize(base.AddDate(0, 0, -7), base, calendar), "2021-08-29")
+}
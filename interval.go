@@ -0,0 +1,345 @@
+package dateutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval the calendar difference of two times, the same as php's DateInterval
+type Interval struct {
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+	// Invert is true when the interval is negative
+	Invert bool
+	// TotalDays the total days of the difference, -1 if the interval is not made by 'Diff'
+	TotalDays int
+}
+
+// Diff get the difference from 'a' to 'b', the arguments can be any type 'DateTime' accepted
+// if 'b' is before 'a', the interval will be inverted
+func Diff(a, b interface{}) (*Interval, error) {
+	start, err := DateTime(a)
+	if err != nil {
+		return nil, err
+	}
+	end, err := DateTime(b)
+	if err != nil {
+		return nil, err
+	}
+	return diffTime(start, end), nil
+}
+
+// get the difference of two times in the location of the start time
+func diffTime(start, end time.Time) *Interval {
+	interval := &Interval{}
+	end = end.In(start.Location())
+	if end.Before(start) {
+		start, end = end, start
+		interval.Invert = true
+	}
+	interval.Years = end.Year() - start.Year()
+	interval.Months = int(end.Month()) - int(start.Month())
+	interval.Days = end.Day() - start.Day()
+	interval.Hours = end.Hour() - start.Hour()
+	interval.Minutes = end.Minute() - start.Minute()
+	interval.Seconds = end.Second() - start.Second()
+	interval.Nanoseconds = end.Nanosecond() - start.Nanosecond()
+	// borrow from the bigger units
+	if interval.Nanoseconds < 0 {
+		interval.Nanoseconds += int(time.Second)
+		interval.Seconds--
+	}
+	if interval.Seconds < 0 {
+		interval.Seconds += 60
+		interval.Minutes--
+	}
+	if interval.Minutes < 0 {
+		interval.Minutes += 60
+		interval.Hours--
+	}
+	if interval.Hours < 0 {
+		interval.Hours += 24
+		interval.Days--
+	}
+	// borrow the days of the months from the start time, the same as php
+	year, month := start.Year(), start.Month()
+	for interval.Days < 0 {
+		interval.Days += daysInMonth(year, month)
+		interval.Months--
+		if month == time.December {
+			year, month = year+1, time.January
+		} else {
+			month++
+		}
+	}
+	if interval.Months < 0 {
+		interval.Months += 12
+		interval.Years--
+	}
+	// the total days, reduce a day if the clock of the end is before the start
+	interval.TotalDays = daysBetween(start, end)
+	startClock := time.Date(2000, 1, 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+	endClock := time.Date(2000, 1, 1, end.Hour(), end.Minute(), end.Second(), end.Nanosecond(), time.UTC)
+	if endClock.Before(startClock) {
+		interval.TotalDays--
+	}
+	return interval
+}
+
+// Format the interval, the same as php's DateInterval::format
+// '%Y' '%M' '%D' '%H' '%I' '%S' with at least 2 digits, '%y' '%m' '%d' '%h' '%i' '%s' without leading zeros
+// '%a' the total days, '%F' '%f' the microseconds, '%R' the sign '-' or '+', '%r' the sign '-' when negative
+func (interval *Interval) Format(format string) string {
+	var result strings.Builder
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		if ch != '%' || i+1 == len(format) {
+			result.WriteByte(ch)
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'Y':
+			result.WriteString(fmt.Sprintf("%02d", interval.Years))
+		case 'y':
+			result.WriteString(strconv.Itoa(interval.Years))
+		case 'M':
+			result.WriteString(fmt.Sprintf("%02d", interval.Months))
+		case 'm':
+			result.WriteString(strconv.Itoa(interval.Months))
+		case 'D':
+			result.WriteString(fmt.Sprintf("%02d", interval.Days))
+		case 'd':
+			result.WriteString(strconv.Itoa(interval.Days))
+		case 'H':
+			result.WriteString(fmt.Sprintf("%02d", interval.Hours))
+		case 'h':
+			result.WriteString(strconv.Itoa(interval.Hours))
+		case 'I':
+			result.WriteString(fmt.Sprintf("%02d", interval.Minutes))
+		case 'i':
+			result.WriteString(strconv.Itoa(interval.Minutes))
+		case 'S':
+			result.WriteString(fmt.Sprintf("%02d", interval.Seconds))
+		case 's':
+			result.WriteString(strconv.Itoa(interval.Seconds))
+		case 'F':
+			result.WriteString(fmt.Sprintf("%06d", interval.Nanoseconds/1e3))
+		case 'f':
+			result.WriteString(strconv.Itoa(interval.Nanoseconds / 1e3))
+		case 'a':
+			if interval.TotalDays < 0 {
+				result.WriteString("(unknown)")
+			} else {
+				result.WriteString(strconv.Itoa(interval.TotalDays))
+			}
+		case 'R':
+			if interval.Invert {
+				result.WriteByte('-')
+			} else {
+				result.WriteByte('+')
+			}
+		case 'r':
+			if interval.Invert {
+				result.WriteByte('-')
+			}
+		case '%':
+			result.WriteByte('%')
+		default:
+			// keep the unknown directive
+			result.WriteByte('%')
+			result.WriteByte(format[i])
+		}
+	}
+	return result.String()
+}
+
+var (
+	// ISO 8601 duration, e.g. "P1Y2M10DT2H30M", "PT0.5S", "P2W"
+	isoDurationRule = regexp.MustCompile(`^([+-])?P(?:([0-9]+(?:[.,][0-9]+)?)Y)?(?:([0-9]+(?:[.,][0-9]+)?)M)?(?:([0-9]+(?:[.,][0-9]+)?)W)?(?:([0-9]+(?:[.,][0-9]+)?)D)?(?:T(?:([0-9]+(?:[.,][0-9]+)?)H)?(?:([0-9]+(?:[.,][0-9]+)?)M)?(?:([0-9]+(?:[.,][0-9]+)?)S)?)?$`)
+)
+
+// ParseISODuration parse the ISO 8601 duration, e.g. "P1Y2M10DT2H30M", "P2W", "PT0.5S"
+// only the last component can have a fraction, the fraction will be carried to the smaller units,
+// a month is taken as 30 days when carrying the fraction of months to days
+func ParseISODuration(value string) (*Interval, error) {
+	matchs := isoDurationRule.FindStringSubmatch(strings.TrimSpace(value))
+	if matchs == nil || strings.HasSuffix(value, "T") {
+		return nil, fmt.Errorf("wrong ISO 8601 duration:'%s'", value)
+	}
+	interval := &Interval{
+		Invert:    matchs[1] == "-",
+		TotalDays: -1,
+	}
+	var (
+		weeks    int
+		fraction float64
+		hasValue bool
+		fracUnit int
+	)
+	fields := []*int{&interval.Years, &interval.Months, &weeks, &interval.Days, &interval.Hours, &interval.Minutes, &interval.Seconds}
+	for index, field := range fields {
+		num := matchs[index+2]
+		if num == "" {
+			continue
+		}
+		if fraction > 0 {
+			return nil, fmt.Errorf("only the last component can have a fraction:'%s'", value)
+		}
+		hasValue = true
+		num = strings.Replace(num, ",", ".", 1)
+		if dot := strings.IndexByte(num, '.'); dot >= 0 {
+			fraction, _ = strconv.ParseFloat("0"+num[dot:], 64)
+			fracUnit = index
+			num = num[:dot]
+		}
+		*field, _ = strconv.Atoi(num)
+	}
+	if !hasValue {
+		return nil, fmt.Errorf("wrong ISO 8601 duration:'%s'", value)
+	}
+	interval.Days += weeks * 7
+	if fraction > 0 {
+		interval.addFraction(fracUnit, fraction)
+	}
+	return interval, nil
+}
+
+// carry the fraction of the unit to the smaller units
+// the unit index is the same as the ISO 8601 duration: Y, M, W, D, H, M, S
+func (interval *Interval) addFraction(unit int, fraction float64) {
+	// the calendar units
+	switch unit {
+	case 0:
+		fraction *= 12
+		months := int(fraction)
+		interval.Months += months
+		fraction -= float64(months)
+		fallthrough
+	case 1:
+		fraction *= 30
+	case 2:
+		fraction *= 7
+	}
+	if unit <= 3 {
+		days := int(fraction)
+		interval.Days += days
+		fraction = (fraction - float64(days)) * 24
+		unit = 4
+	}
+	// the time units, use nanoseconds to avoid the precision lost
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	nanoseconds := time.Duration(fraction*float64(units[unit-4]) + 0.5)
+	interval.Hours += int(nanoseconds / time.Hour)
+	interval.Minutes += int(nanoseconds % time.Hour / time.Minute)
+	interval.Seconds += int(nanoseconds % time.Minute / time.Second)
+	interval.Nanoseconds += int(nanoseconds % time.Second)
+}
+
+// String format the interval to the ISO 8601 duration, e.g. "P1Y2M10DT2H30M", "PT0.5S"
+func (interval *Interval) String() string {
+	var result strings.Builder
+	if interval.Invert {
+		result.WriteByte('-')
+	}
+	result.WriteByte('P')
+	for _, cur := range []struct {
+		value int
+		unit  byte
+	}{
+		{interval.Years, 'Y'},
+		{interval.Months, 'M'},
+		{interval.Days, 'D'},
+	} {
+		if cur.value != 0 {
+			result.WriteString(strconv.Itoa(cur.value))
+			result.WriteByte(cur.unit)
+		}
+	}
+	if interval.Hours != 0 || interval.Minutes != 0 || interval.Seconds != 0 || interval.Nanoseconds != 0 {
+		result.WriteByte('T')
+		if interval.Hours != 0 {
+			result.WriteString(strconv.Itoa(interval.Hours))
+			result.WriteByte('H')
+		}
+		if interval.Minutes != 0 {
+			result.WriteString(strconv.Itoa(interval.Minutes))
+			result.WriteByte('M')
+		}
+		if interval.Seconds != 0 || interval.Nanoseconds != 0 {
+			result.WriteString(strconv.Itoa(interval.Seconds))
+			if interval.Nanoseconds != 0 {
+				frac := strings.TrimRight(fmt.Sprintf("%09d", interval.Nanoseconds), "0")
+				result.WriteString("." + frac)
+			}
+			result.WriteByte('S')
+		}
+	} else if result.Len() == 1 || (interval.Invert && result.Len() == 2) {
+		// zero duration
+		result.WriteString("T0S")
+	}
+	return result.String()
+}
+
+// AddTo add the interval to the time, the years and months are added first,
+// if the day is not in the target month, it will be clamped to the last day of the month,
+// e.g. "2021-01-31" add "P1M" will be "2021-02-28"
+// then add the days by the calendar and the hours, minutes, seconds by the elapsed time
+func (interval *Interval) AddTo(t time.Time) time.Time {
+	sign := 1
+	if interval.Invert {
+		sign = -1
+	}
+	t = AddMonthsNoOverflow(t, sign*(interval.Years*12+interval.Months))
+	t = t.AddDate(0, 0, sign*interval.Days)
+	return t.Add(time.Duration(sign) * interval.clock())
+}
+
+// the elapsed time of the hours, minutes, seconds and nanoseconds
+func (interval *Interval) clock() time.Duration {
+	return time.Duration(interval.Hours)*time.Hour +
+		time.Duration(interval.Minutes)*time.Minute +
+		time.Duration(interval.Seconds)*time.Second +
+		time.Duration(interval.Nanoseconds)
+}
+
+// AddMonthsNoOverflow add months to the time, the day will be clamped to the last day of the target month
+// e.g. "2021-01-31" add 1 month will be "2021-02-28", but 'AddDate' will be "2021-03-03"
+func AddMonthsNoOverflow(t time.Time, months int) time.Time {
+	if months == 0 {
+		return t
+	}
+	year, month, day := t.Date()
+	total := year*12 + int(month) - 1 + months
+	year, month = floorDiv(total, 12), time.Month(floorMod(total, 12)+1)
+	if days := daysInMonth(year, month); day > days {
+		day = days
+	}
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// AddYearsNoOverflow add years to the time, "2020-02-29" add 1 year will be "2021-02-28"
+func AddYearsNoOverflow(t time.Time, years int) time.Time {
+	return AddMonthsNoOverflow(t, years*12)
+}
+
+// the floor division, -1 / 12 = -1
+func floorDiv(a, b int) int {
+	if a < 0 && a%b != 0 {
+		return a/b - 1
+	}
+	return a / b
+}
+
+// the floor modulo, -1 % 12 = 11
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
@@ -0,0 +1,164 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	// calendar breakdown
+	if interval, err := Diff("2020-06-02 10:00:00", "2021-09-05 18:07:06"); err == nil {
+		assert.Equal(t, interval.Format("%y %m %d %h %i %s"), "1 3 3 8 7 6")
+		assert.Equal(t, interval.Format("%R%a days"), "+460 days")
+		assert.False(t, interval.Invert)
+	} else {
+		assert.Fail(t, "Diff fail")
+	}
+	// inverted
+	if interval, err := Diff("2021-09-05", "2021-08-30"); err == nil {
+		assert.Equal(t, interval.Format("%R%d %r%a"), "-6 -6")
+		assert.True(t, interval.Invert)
+	} else {
+		assert.Fail(t, "Diff inverted fail")
+	}
+	// borrow the days of the start month
+	if interval, err := Diff("2021-01-31", "2021-03-01"); err == nil {
+		assert.Equal(t, interval.Months, 1)
+		assert.Equal(t, interval.Days, 1)
+		assert.Equal(t, interval.TotalDays, 29)
+	} else {
+		assert.Fail(t, "Diff month end fail")
+	}
+	// the clock of the end is before the start
+	if interval, err := Diff("2021-09-04 18:00:00", "2021-09-05 06:00:00"); err == nil {
+		assert.Equal(t, interval.Format("%D %H %a"), "00 12 0")
+	} else {
+		assert.Fail(t, "Diff hours fail")
+	}
+	// time and timestamp
+	start := makeTestTime()
+	if interval, err := Diff(start, start.Unix()+90); err == nil {
+		assert.Equal(t, interval.Format("%I:%S.%F %%"), "01:29.987654 %")
+	} else {
+		assert.Fail(t, "Diff timestamp fail")
+	}
+	if _, err := Diff("2021-09-05", "wrong date"); err == nil {
+		assert.Fail(t, "Diff wrong date ok")
+	}
+	// unknown directive and days
+	interval := &Interval{Years: 1, TotalDays: -1}
+	assert.Equal(t, interval.Format("%Y %a %x"), "01 (unknown) %x")
+}
+
+func TestISODuration(t *testing.T) {
+	if interval, err := ParseISODuration("P1Y2M10DT2H30M"); err == nil {
+		assert.Equal(t, interval.Format("%y %m %d %h %i %s %a"), "1 2 10 2 30 0 (unknown)")
+		assert.Equal(t, interval.String(), "P1Y2M10DT2H30M")
+	} else {
+		assert.Fail(t, "ParseISODuration P1Y2M10DT2H30M fail")
+	}
+	// weeks
+	if interval, err := ParseISODuration("P2W"); err == nil {
+		assert.Equal(t, interval.Days, 14)
+		assert.Equal(t, interval.String(), "P14D")
+	} else {
+		assert.Fail(t, "ParseISODuration P2W fail")
+	}
+	// fractions
+	if interval, err := ParseISODuration("PT0.5S"); err == nil {
+		assert.Equal(t, interval.Nanoseconds, 500000000)
+		assert.Equal(t, interval.String(), "PT0.5S")
+	} else {
+		assert.Fail(t, "ParseISODuration PT0.5S fail")
+	}
+	if interval, err := ParseISODuration("P1DT1,25H"); err == nil {
+		assert.Equal(t, interval.String(), "P1DT1H15M")
+	} else {
+		assert.Fail(t, "ParseISODuration P1DT1,25H fail")
+	}
+	if interval, err := ParseISODuration("P1.5Y"); err == nil {
+		assert.Equal(t, interval.String(), "P1Y6M")
+	} else {
+		assert.Fail(t, "ParseISODuration P1.5Y fail")
+	}
+	if interval, err := ParseISODuration("P0.5W"); err == nil {
+		assert.Equal(t, interval.String(), "P3DT12H")
+	} else {
+		assert.Fail(t, "ParseISODuration P0.5W fail")
+	}
+	// negative and zero
+	if interval, err := ParseISODuration("-P1D"); err == nil {
+		assert.True(t, interval.Invert)
+		assert.Equal(t, interval.String(), "-P1D")
+	} else {
+		assert.Fail(t, "ParseISODuration -P1D fail")
+	}
+	assert.Equal(t, (&Interval{}).String(), "PT0S")
+	// wrong durations
+	for _, value := range []string{"P", "PT", "P1.5Y2M", "1Y", "P1H", "PT1D"} {
+		if _, err := ParseISODuration(value); err == nil {
+			assert.Fail(t, "ParseISODuration wrong duration '"+value+"' ok")
+		}
+	}
+	// the diff result
+	if interval, err := Diff("2020-06-02 10:00:00", "2021-09-05 18:07:06"); err == nil {
+		assert.Equal(t, interval.String(), "P1Y3M3DT8H7M6S")
+	} else {
+		assert.Fail(t, "Diff String fail")
+	}
+}
+
+func TestIntervalAddTo(t *testing.T) {
+	start := time.Date(2021, time.January, 31, 10, 0, 0, 0, time.UTC)
+	if interval, err := ParseISODuration("P1M"); err == nil {
+		assert.Equal(t, interval.AddTo(start).Format("2006-01-02"), "2021-02-28")
+	} else {
+		assert.Fail(t, "AddTo P1M fail")
+	}
+	if interval, err := ParseISODuration("P1Y1M"); err == nil {
+		leap := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, interval.AddTo(leap).Format("2006-01-02"), "2021-02-28")
+	} else {
+		assert.Fail(t, "AddTo P1Y1M fail")
+	}
+	if interval, err := ParseISODuration("-P2M1DT12H"); err == nil {
+		assert.Equal(t, interval.AddTo(start).Format("2006-01-02 15:04"), "2020-11-28 22:00")
+	} else {
+		assert.Fail(t, "AddTo -P2M1DT12H fail")
+	}
+	// the days are calendar days, the hours are elapsed time
+	newYork, _ := time.LoadLocation("America/New_York")
+	dst := time.Date(2021, time.March, 13, 12, 0, 0, 0, newYork)
+	if interval, err := ParseISODuration("P1D"); err == nil {
+		assert.Equal(t, interval.AddTo(dst).Format("2006-01-02 15:04"), "2021-03-14 12:00")
+	} else {
+		assert.Fail(t, "AddTo P1D fail")
+	}
+	if interval, err := ParseISODuration("PT24H"); err == nil {
+		assert.Equal(t, interval.AddTo(dst).Format("2006-01-02 15:04"), "2021-03-14 13:00")
+	} else {
+		assert.Fail(t, "AddTo PT24H fail")
+	}
+	// diff then add back
+	if interval, err := Diff("2021-01-31", "2021-03-01"); err == nil {
+		date, _ := DateTime("2021-01-31")
+		assert.Equal(t, interval.AddTo(date).Format("2006-01-02"), "2021-03-01")
+	} else {
+		assert.Fail(t, "Diff AddTo fail")
+	}
+}
+
+func TestAddMonthsNoOverflow(t *testing.T) {
+	date := time.Date(2021, time.January, 31, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, AddMonthsNoOverflow(date, 1).Format("2006-01-02 15:04"), "2021-02-28 10:00")
+	assert.Equal(t, AddMonthsNoOverflow(date, 3).Format("2006-01-02"), "2021-04-30")
+	assert.Equal(t, AddMonthsNoOverflow(date, -2).Format("2006-01-02"), "2020-11-30")
+	assert.Equal(t, AddMonthsNoOverflow(date, -13).Format("2006-01-02"), "2019-12-31")
+	assert.Equal(t, AddMonthsNoOverflow(date, 0), date)
+	leap := time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, AddYearsNoOverflow(leap, 1).Format("2006-01-02"), "2021-02-28")
+	assert.Equal(t, AddYearsNoOverflow(leap, 4).Format("2006-01-02"), "2024-02-29")
+	assert.Equal(t, AddYearsNoOverflow(leap, -1).Format("2006-01-02"), "2019-02-28")
+}
@@ -0,0 +1,414 @@
+package dateutil
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Locale the names of the months and weekdays in a language
+type Locale struct {
+	// Name the name of the locale, e.g. "de", "fr"
+	Name string
+	// Months the full names of the months from January
+	Months [12]string
+	// ShortMonths the abbreviations of the months
+	ShortMonths [12]string
+	// GenitiveMonths the genitive case of the months used with a day, e.g. "5 сентября", empty if the same as 'Months'
+	GenitiveMonths [12]string
+	// MonthVariants the other names of the months can be parsed, e.g. "Jänner", "setiembre"
+	MonthVariants [12][]string
+	// Weekdays the full names of the weekdays from Sunday
+	Weekdays [7]string
+	// ShortWeekdays the abbreviations of the weekdays
+	ShortWeekdays [7]string
+	// WeekdayVariants the other names of the weekdays can be parsed, e.g. "Sonnabend"
+	WeekdayVariants [7][]string
+	// Fillers the words ignored in parsing, e.g. the "de" of "5 de septiembre de 2021"
+	Fillers []string
+	// Meridiems the strings before and after noon in formatting, 'A' is the uppercase one, empty to use "am" and "pm"
+	Meridiems [2]string
+	// Ordinal the suffix of the day in formatting, e.g. "er" of "1er" in french, nil for no suffix
+	Ordinal func(day int) string
+}
+
+var (
+	locales = map[string]*Locale{
+		"en": {
+			Name:          "en",
+			Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+			Ordinal:       englishOrdinal,
+		},
+		"de": {
+			Name:            "de",
+			Months:          [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+			ShortMonths:     [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+			MonthVariants:   [12][]string{{"Jänner", "Jän"}, {"Feber"}, {"Mrz"}, nil, nil, nil, nil, nil, {"Sept"}, nil, nil, nil},
+			Weekdays:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+			ShortWeekdays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+			WeekdayVariants: [7][]string{nil, nil, nil, nil, nil, nil, {"Sonnabend"}},
+			Fillers:         []string{"am", "den"},
+			Ordinal:         suffixOrdinal("."),
+		},
+		"fr": {
+			Name:          "fr",
+			Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+			ShortMonths:   [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+			MonthVariants: [12][]string{nil, {"fév"}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+			Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+			ShortWeekdays: [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+			Fillers:       []string{"le"},
+			Ordinal:       firstOrdinal("er"),
+		},
+		"es": {
+			Name:          "es",
+			Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			ShortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+			MonthVariants: [12][]string{nil, nil, nil, nil, nil, nil, nil, nil, {"setiembre", "sept", "set"}, nil, nil, nil},
+			Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+			Fillers:       []string{"de", "del"},
+			Meridiems:     [2]string{"a. m.", "p. m."},
+			Ordinal:       firstOrdinal("º"),
+		},
+		"pt": {
+			Name:            "pt",
+			Months:          [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+			ShortMonths:     [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+			Weekdays:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+			ShortWeekdays:   [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+			WeekdayVariants: [7][]string{nil, {"segunda"}, {"terça"}, {"quarta"}, {"quinta"}, {"sexta"}, nil},
+			Fillers:         []string{"de", "do"},
+			Ordinal:         firstOrdinal("º"),
+		},
+		"it": {
+			Name:          "it",
+			Months:        [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+			ShortMonths:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+			Weekdays:      [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+			ShortWeekdays: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+			Ordinal:       firstOrdinal("º"),
+		},
+		"nl": {
+			Name:          "nl",
+			Months:        [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+			ShortMonths:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+			MonthVariants: [12][]string{nil, nil, {"maa"}, nil, nil, nil, nil, nil, {"sept"}, nil, nil, nil},
+			Weekdays:      [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+			ShortWeekdays: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+			Meridiems:     [2]string{"a.m.", "p.m."},
+			Ordinal:       suffixOrdinal("e"),
+		},
+		"ru": {
+			Name:           "ru",
+			Months:         [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+			ShortMonths:    [12]string{"янв", "фев", "мар", "апр", "май", "июн", "июл", "авг", "сен", "окт", "ноя", "дек"},
+			GenitiveMonths: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+			MonthVariants:  [12][]string{nil, {"февр"}, nil, nil, nil, nil, nil, nil, {"сент"}, nil, {"нояб"}, nil},
+			Weekdays:       [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+			ShortWeekdays:  [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+			Fillers:        []string{"г.", "г", "года"},
+			Meridiems:      [2]string{"дп", "пп"},
+		},
+		"pl": {
+			Name:           "pl",
+			Months:         [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+			ShortMonths:    [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+			GenitiveMonths: [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+			Weekdays:       [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+			ShortWeekdays:  [7]string{"niedz", "pon", "wt", "śr", "czw", "pt", "sob"},
+			Fillers:        []string{"r.", "r", "roku"},
+		},
+		"zh": {
+			Name:            "zh",
+			Months:          [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+			ShortMonths:     [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			Weekdays:        [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+			ShortWeekdays:   [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+			WeekdayVariants: [7][]string{{"星期天", "周天", "週日"}, {"週一"}, {"週二"}, {"週三"}, {"週四"}, {"週五"}, {"週六"}},
+			Meridiems:       [2]string{"上午", "下午"},
+			Ordinal:         suffixOrdinal("日"),
+		},
+		"ja": {
+			Name:            "ja",
+			Months:          [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			ShortMonths:     [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			MonthVariants:   [12][]string{{"一月"}, {"二月"}, {"三月"}, {"四月"}, {"五月"}, {"六月"}, {"七月"}, {"八月"}, {"九月"}, {"十月"}, {"十一月"}, {"十二月"}},
+			Weekdays:        [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+			ShortWeekdays:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+			WeekdayVariants: [7][]string{{"日曜"}, {"月曜"}, {"火曜"}, {"水曜"}, {"木曜"}, {"金曜"}, {"土曜"}},
+			Meridiems:       [2]string{"午前", "午後"},
+			Ordinal:         suffixOrdinal("日"),
+		},
+		"ko": {
+			Name:          "ko",
+			Months:        [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+			ShortMonths:   [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+			Weekdays:      [7]string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+			ShortWeekdays: [7]string{"일", "월", "화", "수", "목", "금", "토"},
+			Meridiems:     [2]string{"오전", "오후"},
+			Ordinal:       suffixOrdinal("일"),
+		},
+		"ar": {
+			Name:          "ar",
+			Months:        [12]string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+			ShortMonths:   [12]string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+			MonthVariants: [12][]string{nil, nil, nil, {"ابريل"}, nil, nil, nil, {"اغسطس"}, nil, {"اكتوبر"}, nil, nil},
+			Weekdays:      [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+			ShortWeekdays: [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+			Meridiems:     [2]string{"ص", "م"},
+		},
+	}
+	localeMutex sync.RWMutex
+	// the letters with diacritics, "é" can be typed as "e"
+	diacriticReplacer = strings.NewReplacer(
+		"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a",
+		"ç", "c",
+		"è", "e", "é", "e", "ê", "e", "ë", "e",
+		"ì", "i", "í", "i", "î", "i", "ï", "i",
+		"ñ", "n",
+		"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ù", "u", "ú", "u", "û", "u", "ü", "u",
+		"ё", "е",
+		"ą", "a", "ć", "c", "ę", "e", "ł", "l", "ń", "n", "ś", "s", "ź", "z", "ż", "z",
+	)
+)
+
+// RegisterLocale add or replace the locale by the name, the locale can be used by 'WithLocale'
+func RegisterLocale(locale *Locale) {
+	name := strings.ToLower(locale.Name)
+	localeMutex.Lock()
+	locales[name] = locale
+	localeMutex.Unlock()
+	// remove the patterns made by the previous locale
+	patternMutex.Lock()
+	for key := range allPatternInfo {
+		if strings.HasSuffix(key, "@"+name) {
+			delete(allPatternInfo, key)
+		}
+	}
+	patternMutex.Unlock()
+	findMutex.Lock()
+	delete(localeFindPatterns, name)
+	findMutex.Unlock()
+}
+
+// GetLocale get the registered locale by the name, e.g. "en", "de", "fr", "es", "pt", "it", "nl", "ru", "pl", "zh", "ja", "ko", "ar"
+func GetLocale(name string) (*Locale, bool) {
+	localeMutex.RLock()
+	defer localeMutex.RUnlock()
+	locale, ok := locales[strings.ToLower(name)]
+	return locale, ok
+}
+
+// remove the filler words of the locale from the string
+func (locale *Locale) removeFillers(value string) string {
+	if locale == nil || len(locale.Fillers) == 0 {
+		return value
+	}
+	words := strings.Fields(value)
+	result := words[:0]
+	for _, word := range words {
+		filler := false
+		for _, cur := range locale.Fillers {
+			if strings.EqualFold(cur, word) {
+				filler = true
+				break
+			}
+		}
+		if !filler {
+			result = append(result, word)
+		}
+	}
+	if len(result) == len(words) {
+		return value
+	}
+	return strings.Join(result, " ")
+}
+
+// lowercase the name and remove the diacritics
+func foldName(name string) string {
+	return diacriticReplacer.Replace(strings.ToLower(name))
+}
+
+// get the names of the months, the short ones are only the abbreviations
+func (locale *Locale) monthNames(short bool) [12][]string {
+	var names [12][]string
+	for index := range names {
+		names[index] = []string{locale.ShortMonths[index]}
+		if !short {
+			names[index] = append(names[index], locale.Months[index], locale.GenitiveMonths[index])
+			names[index] = append(names[index], locale.MonthVariants[index]...)
+		}
+	}
+	return names
+}
+
+// get the names of the weekdays, the short ones are only the abbreviations
+func (locale *Locale) weekdayNames(short bool) [7][]string {
+	var names [7][]string
+	for index := range names {
+		names[index] = []string{locale.ShortWeekdays[index]}
+		if !short {
+			names[index] = append(names[index], locale.Weekdays[index])
+			names[index] = append(names[index], locale.WeekdayVariants[index]...)
+		}
+	}
+	return names
+}
+
+// get the month from 1 to 12 by the name, 0 if not found or the locale is nil
+func (locale *Locale) monthNum(name string) int {
+	if locale == nil {
+		return 0
+	}
+	name = foldName(strings.TrimSuffix(name, "."))
+	for index, names := range locale.monthNames(false) {
+		for _, cur := range names {
+			if cur != "" && foldName(cur) == name {
+				return index + 1
+			}
+		}
+	}
+	return 0
+}
+
+// get the weekday from 0 to 6 by the name, -1 if not found or the locale is nil
+func (locale *Locale) weekdayNum(name string) int {
+	if locale == nil {
+		return -1
+	}
+	name = foldName(strings.TrimSuffix(name, "."))
+	for index, names := range locale.weekdayNames(false) {
+		for _, cur := range names {
+			if cur != "" && foldName(cur) == name {
+				return index
+			}
+		}
+	}
+	return -1
+}
+
+// make the format list of the locale, the month and weekday placeholders include the names of the locale
+func (locale *Locale) formats(formatList *FormatList) *FormatList {
+	months, shortMonths := locale.monthNames(false), locale.monthNames(true)
+	weekdays, shortWeekdays := locale.weekdayNames(false), locale.weekdayNames(true)
+	expands := map[string][]string{
+		"m": flattenNames(months[:]),
+		"M": flattenNames(shortMonths[:]),
+		"l": flattenNames(weekdays[:]),
+		"D": flattenNames(shortWeekdays[:]),
+	}
+	result := FormatList{}
+	for key, format := range *formatList {
+		if names, ok := expands[key]; ok && len(names) > 0 && strings.HasPrefix(format, "(") {
+			// the names of the locale are tried before the english names
+			format = "(" + strings.Join(names, "|") + "|" + format[1:]
+		}
+		result[key] = format
+	}
+	return &result
+}
+
+// flatten the names with the variants without diacritics, the longer names are tried first
+func flattenNames(names [][]string) []string {
+	var result []string
+	exists := map[string]bool{}
+	for _, cur := range names {
+		for _, name := range cur {
+			for _, variant := range []string{strings.ToLower(name), foldName(name)} {
+				if variant != "" && !exists[variant] {
+					exists[variant] = true
+					result = append(result, variant)
+				}
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return utf8.RuneCountInString(result[i]) > utf8.RuneCountInString(result[j])
+	})
+	for index, name := range result {
+		result[index] = regexp.QuoteMeta(name)
+	}
+	return result
+}
+
+// format the time with a format character by the names of the locale, e.g. 'F', 'M', 'l', 'D', 'a', 'A', 'S'
+// the genitive month names are used if the format has a day, e.g. "5 сентября 2021"
+// if the locale has no name for the character, return false to use the english name
+func (locale *Locale) formatChar(t time.Time, ch byte, withDay bool) (string, bool) {
+	var value string
+	switch ch {
+	case 'F':
+		if value = locale.Months[t.Month()-1]; withDay && locale.GenitiveMonths[t.Month()-1] != "" {
+			value = locale.GenitiveMonths[t.Month()-1]
+		}
+	case 'M':
+		value = locale.ShortMonths[t.Month()-1]
+	case 'l':
+		value = locale.Weekdays[t.Weekday()]
+	case 'D':
+		value = locale.ShortWeekdays[t.Weekday()]
+	case 'a', 'A':
+		if value = locale.Meridiems[t.Hour()/12]; ch == 'A' {
+			value = strings.ToUpper(value)
+		}
+	case 'S':
+		// no suffix if the locale has no ordinal
+		if locale.Ordinal == nil {
+			return "", true
+		}
+		return locale.Ordinal(t.Day()), true
+	}
+	return value, value != ""
+}
+
+// check if the format has a day character, the escaped characters are skipped
+func hasDayChar(format string) bool {
+	for i := 0; i < len(format); i++ {
+		if format[i] == '\\' {
+			i++
+		} else if format[i] == 'd' || format[i] == 'j' {
+			return true
+		}
+	}
+	return false
+}
+
+// the english ordinal suffix of the day, "st", "nd", "rd" or "th"
+func englishOrdinal(day int) string {
+	if day%100 < 11 || day%100 > 13 {
+		switch day % 10 {
+		case 1:
+			return "st"
+		case 2:
+			return "nd"
+		case 3:
+			return "rd"
+		}
+	}
+	return "th"
+}
+
+// the ordinal with the same suffix for all the days, e.g. "5." in german
+func suffixOrdinal(suffix string) func(day int) string {
+	return func(day int) string {
+		return suffix
+	}
+}
+
+// the ordinal with the suffix only for the first day, e.g. "1er" in french
+func firstOrdinal(suffix string) func(day int) string {
+	return func(day int) string {
+		if day == 1 {
+			return suffix
+		}
+		return ""
+	}
+}
@@ -0,0 +1,199 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleParse(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]map[string]string{
+		"de": {
+			"5. September 2021": "2021-09-05",
+			"am 5. März 2021":   "2021-03-05",
+			"5. MÄRZ 2021":      "2021-03-05",
+			"5. Marz 2021":      "2021-03-05",
+			"5 Dez 2021":        "2021-12-05",
+			"5 December 2021":   "2021-12-05",
+		},
+		"fr": {
+			"5 septembre 2021":  "2021-09-05",
+			"le 5 février 2021": "2021-02-05",
+			"5 fevrier 2021":    "2021-02-05",
+			"5 déc. 2021":       "2021-12-05",
+		},
+		"es": {
+			"5 de septiembre de 2021": "2021-09-05",
+			"5 setiembre 2021":        "2021-09-05",
+			"5 mar 2021":              "2021-03-05",
+		},
+		"pt": {
+			"5 de março de 2021": "2021-03-05",
+			"5 de marco de 2021": "2021-03-05",
+		},
+		"it": {
+			"5 settembre 2021": "2021-09-05",
+			"5 mag 2021":       "2021-05-05",
+		},
+		"nl": {
+			"5 maart 2021": "2021-03-05",
+			"5 mei 2021":   "2021-05-05",
+		},
+		"ru": {
+			"5 сентября 2021 г.": "2021-09-05",
+			"5 мая 2021":         "2021-05-05",
+		},
+		"pl": {
+			"5 września 2021 r.":  "2021-09-05",
+			"5 pazdziernika 2021": "2021-10-05",
+		},
+	}
+	for name, values := range cases {
+		for value, expect := range values {
+			if date, err := DateTime(value, utc, WithLocale(name)); err == nil {
+				assert.Equal(t, date.Format("2006-01-02"), expect, name+" "+value)
+			} else {
+				assert.Fail(t, "DateTime locale "+name+" '"+value+"' fail")
+			}
+		}
+	}
+	// the month without day
+	for name, value := range map[string]string{"de": "Jänner 2021", "ru": "Сентябрь 2021", "zh": "十一月 2021", "ja": "2021 9月"} {
+		if result, err := Parse(value, utc, WithLocale(name)); err == nil {
+			assert.Equal(t, result.Precision, PrecisionMonth)
+		} else {
+			assert.Fail(t, "Parse locale "+name+" '"+value+"' fail")
+		}
+	}
+	if date, err := DateTime("Сентябрь 2021", utc, WithLocale("ru")); err == nil {
+		assert.Equal(t, date.Month(), time.September)
+	} else {
+		assert.Fail(t, "DateTime locale month fail")
+	}
+	// the weekday and the fuzzy words
+	if date, err := DateTime("Termin am Montag, 5. September 2021 um 18:00", utc, WithLocale("de"), WithFuzzy()); err == nil {
+		assert.Equal(t, date.Format("2006-01-02 15:04"), "2021-09-05 18:00")
+	} else {
+		assert.Fail(t, "DateTime locale fuzzy fail")
+	}
+	if date, err := DateTime("nächsten Freitag", utc, WithLocale("de"), WithFuzzy()); err == nil {
+		assert.Equal(t, date.Weekday(), time.Friday)
+	} else {
+		assert.Fail(t, "DateTime locale weekday fail")
+	}
+	// without the locale
+	if _, err := DateTime("5 septembre 2021", utc); err == nil {
+		assert.Fail(t, "DateTime without locale ok")
+	}
+	// the unknown locale is ignored
+	if _, err := DateTime("5 septembre 2021", utc, WithLocale("xx")); err == nil {
+		assert.Fail(t, "DateTime unknown locale ok")
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	locale := &Locale{
+		Name:          "test",
+		Months:        [12]string{"m01", "m02", "m03", "m04", "m05", "m06", "m07", "m08", "m09", "m10", "m11", "m12"},
+		ShortMonths:   [12]string{"s01", "s02", "s03", "s04", "s05", "s06", "s07", "s08", "s09", "s10", "s11", "s12"},
+		Weekdays:      [7]string{"w0", "w1", "w2", "w3", "w4", "w5", "w6"},
+		ShortWeekdays: [7]string{"x0", "x1", "x2", "x3", "x4", "x5", "x6"},
+	}
+	RegisterLocale(locale)
+	if found, ok := GetLocale("TEST"); ok {
+		assert.Equal(t, found, locale)
+	} else {
+		assert.Fail(t, "GetLocale fail")
+	}
+	if date, err := DateTime("5 m09 2021", utc, WithLocale("test")); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-09-05")
+	} else {
+		assert.Fail(t, "DateTime registered locale fail")
+	}
+	// replace the locale
+	locale = &Locale{
+		Name:   "test",
+		Months: [12]string{"n01", "n02", "n03", "n04", "n05", "n06", "n07", "n08", "n09", "n10", "n11", "n12"},
+	}
+	RegisterLocale(locale)
+	if date, err := DateTime("5 n10 2021", utc, WithLocale("test")); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-10-05")
+	} else {
+		assert.Fail(t, "DateTime replaced locale fail")
+	}
+	if _, err := DateTime("5 m09 2021", utc, WithLocale("test")); err == nil {
+		assert.Fail(t, "DateTime replaced locale old names ok")
+	}
+}
+
+func TestDateFormatLocale(t *testing.T) {
+	date := time.Date(2021, time.September, 1, 18, 7, 6, 0, time.UTC)
+	cases := map[string]map[string]string{
+		"en": {
+			"l, F jS Y": "Wednesday, September 1st 2021",
+		},
+		"de": {
+			"l, j. F Y": "Mittwoch, 1. September 2021",
+			"D jS M":    "Mi 1. Sep",
+			"g:i A":     "6:07 PM",
+		},
+		"fr": {
+			"l jS F Y": "mercredi 1er septembre 2021",
+			"D j M":    "mer 1 sept",
+		},
+		"es": {
+			"j \\d\\e F \\d\\e Y": "1 de septiembre de 2021",
+			"g:i a":               "6:07 p. m.",
+		},
+		"ru": {
+			"j F Y": "1 сентября 2021",
+			"F Y":   "сентябрь 2021",
+			"H:i A": "18:07 ПП",
+		},
+		"pl": {
+			"j F Y": "1 września 2021",
+			"F Y":   "wrzesień 2021",
+		},
+		"zh": {
+			"Y年FjS":   "2021年九月1日",
+			"l A g:i": "星期三 下午 6:07",
+		},
+		"ja": {
+			"Y年F jS (D)": "2021年9月 1日 (水)",
+		},
+		"ko": {
+			"Y년 F jS A g시": "2021년 9월 1일 오후 6시",
+		},
+	}
+	for name, formats := range cases {
+		for format, expect := range formats {
+			if value, err := DateFormatLocale(date, format, name); err == nil {
+				assert.Equal(t, value, expect, name+" "+format)
+			} else {
+				assert.Fail(t, "DateFormatLocale "+name+" '"+format+"' fail")
+			}
+		}
+	}
+	// the option and the string target parsed by the locale
+	if value, err := DateFormat("5 septembre 2021", "l j F", WithLocation(time.UTC), WithLocale("fr")); err == nil {
+		assert.Equal(t, value, "dimanche 5 septembre")
+	} else {
+		assert.Fail(t, "DateFormat with locale fail")
+	}
+	if _, err := DateFormatLocale(date, "F", "xx"); err == nil {
+		assert.Fail(t, "DateFormatLocale unknown locale ok")
+	}
+	// the registered locale without meridiems and ordinals
+	RegisterLocale(&Locale{
+		Name:   "format",
+		Months: [12]string{"m01", "m02", "m03", "m04", "m05", "m06", "m07", "m08", "m09", "m10", "m11", "m12"},
+	})
+	if value, err := DateFormatLocale(date, "jS F M a", "format"); err == nil {
+		assert.Equal(t, value, "1 m09 Sep pm")
+	} else {
+		assert.Fail(t, "DateFormatLocale registered locale fail")
+	}
+}
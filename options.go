@@ -0,0 +1,127 @@
+package dateutil
+
+import "time"
+
+// Option for the parse functions
+type Option func(*options)
+
+// options the configurations collected from all the 'Option' arguments
+type options struct {
+	// the location used when the string has no timezone information,
+	// the parsed time will also be changed into this location
+	location *time.Location
+	// clamp the day to the last day of the month instead of overflowing to the next month
+	noOverflow bool
+	// the first day of the week
+	weekStart time.Weekday
+	// the calendar of the business days, nil for the weekdays
+	calendar Calendar
+	// the fiscal calendar for the fiscal notations and format characters
+	fiscal *FiscalCalendar
+	// skip the unknown words in the string
+	fuzzy bool
+	// the names of the months and weekdays
+	locale *Locale
+	// the format characters of the japanese era
+	japaneseEra bool
+	// the calendar of the years, months and days, nil for the gregorian calendar
+	calendarSystem CalendarSystem
+	// fill the missing month and day by the first ones rather than now, used by the ranges
+	fillDate bool
+}
+
+// WithLocation set the location of the parsed time
+func WithLocation(location *time.Location) Option {
+	return func(o *options) {
+		if location != nil {
+			o.location = location
+		}
+	}
+}
+
+// WithNoOverflow don't overflow the day of the relative offsets to the next month, the literal dates are not changed,
+// e.g. "2021-01-31 +1 month" will be "2021-02-28" rather than "2021-03-03"
+func WithNoOverflow() Option {
+	return func(o *options) {
+		o.noOverflow = true
+	}
+}
+
+// WithWeekStart set the first day of the week, default is monday
+func WithWeekStart(weekday time.Weekday) Option {
+	return func(o *options) {
+		if weekday >= time.Sunday && weekday <= time.Saturday {
+			o.weekStart = weekday
+		}
+	}
+}
+
+// WithBusinessCalendar set the calendar used by the relative offset "+N weekdays"
+func WithBusinessCalendar(calendar Calendar) Option {
+	return func(o *options) {
+		o.calendar = calendar
+	}
+}
+
+// WithFiscalCalendar parse the fiscal notations like "Q3 2021", "FY2022 Q1" by the fiscal calendar,
+// and enable the format characters 'Q' the fiscal quarter and 'K' the fiscal year of 'DateFormat'
+func WithFiscalCalendar(calendar *FiscalCalendar) Option {
+	return func(o *options) {
+		o.fiscal = calendar
+	}
+}
+
+// WithJapaneseEra enable the format characters 'E' the japanese era name and 'J' the year of the era of 'DateFormat',
+// e.g. "EJ年n月j日" is "令和3年9月5日"
+func WithJapaneseEra() Option {
+	return func(o *options) {
+		o.japaneseEra = true
+	}
+}
+
+// WithCalendarSystem parse the years, months and days of the strings in the calendar,
+// and format the characters 'Y', 'y', 'm', 'n', 'd', 'j', 't' and the month names 'F', 'M' of the hijri calendar
+// of 'DateFormat' in the calendar,
+// e.g. "2564-09-05" with 'ThaiBuddhistCalendar' is 2021-09-05, the years are not expanded by the current century
+func WithCalendarSystem(calendar CalendarSystem) Option {
+	return func(o *options) {
+		o.calendarSystem = calendar
+	}
+}
+
+// WithFuzzy skip the words which don't belong to any date, time or timezone,
+// e.g. "Meeting on Sunday, Sep 5th 2021 at 6pm please", the skipped words are in the 'Skipped' of 'Parse' result
+func WithFuzzy() Option {
+	return func(o *options) {
+		o.fuzzy = true
+	}
+}
+
+// WithLocale parse the names of the months and weekdays in the registered locale besides the english names,
+// e.g. "5. März 2021" with "de", the unknown locale is ignored
+func WithLocale(name string) Option {
+	return func(o *options) {
+		if locale, ok := GetLocale(name); ok {
+			o.locale = locale
+		}
+	}
+}
+
+// fill the missing month and day of the parsed date by the first ones, e.g. "Feb 2021" is 2021-02-01
+func withFillDate() Option {
+	return func(o *options) {
+		o.fillDate = true
+	}
+}
+
+// make the options with default values
+func makeOptions(opts []Option) *options {
+	o := &options{
+		location:  time.Local,
+		weekStart: time.Monday,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
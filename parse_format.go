@@ -0,0 +1,579 @@
+package dateutil
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// fieldFlag mark which fields have been parsed
+type fieldFlag uint32
+
+const (
+	hasYear fieldFlag = 1 << iota
+	hasMonth
+	hasDay
+	hasYearDay
+	hasWeek
+	hasWeekday
+	hasHour
+	hasMinute
+	hasSecond
+	hasFraction
+	hasMeridian
+	hasZone
+	hasLeap
+	hasMonthDays
+	// the fields about the time
+	timeFieldFlags = hasHour | hasMinute | hasSecond | hasFraction
+)
+
+// dateFields the fields parsed from a formatted string
+type dateFields struct {
+	year, month, day, yearDay, week  int
+	hour, minute, second, nanosecond int
+	// golang's weekday, sunday is 0
+	weekday int
+	// the first day of the week number, 0 for ISO-8601 week
+	// 'U' for sunday, 'W' for monday
+	weekMode  byte
+	pm        bool
+	leap      bool
+	monthDays int
+	location  *time.Location
+	flags     fieldFlag
+}
+
+// set the fields to the unix epoch: 1970-01-01 00:00:00
+// if 'onlyUnset' is true, the parsed fields will be kept
+func (f *dateFields) reset(onlyUnset bool) {
+	epoch := dateFields{
+		year:  1970,
+		month: 1,
+		day:   1,
+	}
+	if onlyUnset {
+		all := []struct {
+			flag  fieldFlag
+			field *int
+			value int
+		}{
+			{hasYear, &f.year, epoch.year},
+			{hasMonth, &f.month, epoch.month},
+			{hasDay, &f.day, epoch.day},
+			{hasHour, &f.hour, 0},
+			{hasMinute, &f.minute, 0},
+			{hasSecond, &f.second, 0},
+			{hasFraction, &f.nanosecond, 0},
+		}
+		for _, cur := range all {
+			if f.flags&cur.flag == 0 {
+				*cur.field = cur.value
+				f.flags |= cur.flag
+			}
+		}
+		return
+	}
+	epoch.flags = hasYear | hasMonth | hasDay | timeFieldFlags
+	*f = epoch
+}
+
+// translate the fields to a time struct
+// the fields not parsed will use the current time's value
+func (f *dateFields) toTime(location *time.Location) (time.Time, error) {
+	if f.flags&hasZone > 0 {
+		location = f.location
+	}
+	now := time.Now().In(location)
+	year, month, day := now.Year(), int(now.Month()), now.Day()
+	if f.flags&hasYear > 0 {
+		year = f.year
+	}
+	if f.flags&hasMonth > 0 {
+		month = f.month
+	}
+	if f.flags&hasDay > 0 {
+		day = f.day
+	}
+	// if any of the time fields is parsed, the others will be zero
+	hour, minute, second, nanosecond := f.hour, f.minute, f.second, f.nanosecond
+	if f.flags&timeFieldFlags == 0 {
+		hour, minute, second, nanosecond = now.Hour(), now.Minute(), now.Second(), now.Nanosecond()
+	}
+	// 12-hour format with meridian
+	if f.flags&hasMeridian > 0 && f.flags&hasHour > 0 {
+		if hour > 12 || hour == 0 {
+			return time.Time{}, fmt.Errorf("wrong 12-hour format hour '%d'", hour)
+		}
+		hour %= 12
+		if f.pm {
+			hour += 12
+		}
+	}
+	var lastTime time.Time
+	switch {
+	case f.flags&hasYearDay > 0:
+		// the day of the year, start from 0
+		lastTime = time.Date(year, time.January, f.yearDay+1, hour, minute, second, nanosecond, location)
+	case f.flags&hasWeek > 0:
+		// the first day of the week
+		firstDay := time.Monday
+		switch f.weekMode {
+		case 'U':
+			firstDay = time.Sunday
+			lastTime = weekStart(year, f.week, firstDay, location)
+		case 'W':
+			lastTime = weekStart(year, f.week, firstDay, location)
+		default:
+			lastTime = isoWeekStart(year, f.week, location)
+		}
+		lastTime = time.Date(lastTime.Year(), lastTime.Month(), lastTime.Day(), hour, minute, second, nanosecond, location)
+		if f.flags&hasWeekday > 0 {
+			lastTime = lastTime.AddDate(0, 0, (f.weekday-int(firstDay)+7)%7)
+		}
+	default:
+		lastTime = time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, location)
+	}
+	if f.flags&hasWeekday > 0 && f.flags&hasWeek == 0 {
+		lastTime = forwardToWeekday(lastTime, f.weekday)
+	}
+	// check the fields that can't change the time
+	if f.flags&hasLeap > 0 && f.leap != isLeapYear(lastTime.Year()) {
+		return time.Time{}, fmt.Errorf("the leap year flag doesn't match the year '%d'", lastTime.Year())
+	}
+	if f.flags&hasMonthDays > 0 && f.monthDays != daysInMonth(lastTime.Year(), lastTime.Month()) {
+		return time.Time{}, fmt.Errorf("the days '%d' doesn't match the month '%s'", f.monthDays, lastTime.Format("2006-01"))
+	}
+	return lastTime, nil
+}
+
+// get the monday of the ISO-8601 week
+func isoWeekStart(year, week int, location *time.Location) time.Time {
+	// the 4th of january is always in the first week
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+	return monday.AddDate(0, 0, (week-1)*7)
+}
+
+// get the first day of the week, the week 1 start from the first 'firstDay' of the year
+// the days before it are in week 0
+func weekStart(year, week int, firstDay time.Weekday, location *time.Location) time.Time {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	first := jan1.AddDate(0, 0, (int(firstDay)-int(jan1.Weekday())+7)%7)
+	return first.AddDate(0, 0, (week-1)*7)
+}
+
+// formatScanner read the value step by step
+type formatScanner struct {
+	value string
+	pos   int
+	// the locale of the ordinal suffixes, nil for english
+	locale *Locale
+}
+
+// check if all the characters are read
+func (s *formatScanner) done() bool {
+	return s.pos >= len(s.value)
+}
+
+// get the left characters
+func (s *formatScanner) rest() string {
+	return s.value[s.pos:]
+}
+
+// read a number, at least 'min' digits and at most 'max' digits
+// return the number and the count of the digits
+func (s *formatScanner) number(min, max int) (int, int, bool) {
+	start := s.pos
+	for s.pos < len(s.value) && s.pos-start < max && isDigit(s.value[s.pos]) {
+		s.pos++
+	}
+	count := s.pos - start
+	if count < min {
+		s.pos = start
+		return 0, 0, false
+	}
+	num, err := strconv.Atoi(s.value[start:s.pos])
+	if err != nil {
+		s.pos = start
+		return 0, 0, false
+	}
+	return num, count, true
+}
+
+// read one of the full names or short names, ignore case
+// return the index of the name
+func (s *formatScanner) name(fullNames []string, shortNames []string) (int, bool) {
+	rest := s.rest()
+	for index, name := range fullNames {
+		for _, cur := range []string{name, shortNames[index]} {
+			if len(rest) >= len(cur) && strings.EqualFold(rest[:len(cur)], cur) {
+				s.pos += len(cur)
+				return index, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// read a timezone, an identifier, an abbreviation or an offset
+func (s *formatScanner) zone() (*time.Location, bool) {
+	rest := s.rest()
+	if loc := zoneOffsetRule.FindStringSubmatchIndex(rest); loc != nil {
+		sign, hour, minute := rest[loc[2]:loc[3]], rest[loc[4]:loc[5]], ""
+		if loc[6] >= 0 {
+			minute = rest[loc[6]:loc[7]]
+		}
+		s.pos += loc[1]
+		return fixedZone(sign, hour, minute), true
+	}
+	if len(rest) > 0 && (rest[0] == 'Z' || rest[0] == 'z') && (len(rest) == 1 || !isLetter(rest[1])) {
+		s.pos++
+		return time.UTC, true
+	}
+	if name := zoneNameRule.FindString(rest); name != "" {
+		if location, err := time.LoadLocation(name); err == nil {
+			s.pos += len(name)
+			return location, true
+		}
+	}
+	return nil, false
+}
+
+var (
+	// timezone offset, e.g. "+08:00", "-0700", "GMT+8"
+	zoneOffsetRule = regexp.MustCompile(`^(?:GMT|UTC)?([+-])(1[0-4]|0?[0-9])(?::?([0-5][0-9]))?`)
+	// timezone identifier or abbreviation, e.g. "Europe/Amsterdam", "EST"
+	zoneNameRule = regexp.MustCompile(`^[A-Za-z][A-Za-z_]*(?:/[A-Za-z][A-Za-z0-9_+-]*)*`)
+	// the separators can be matched by '#'
+	formatSeparators = ";:/.,-()"
+)
+
+// make a fixed timezone by the offset
+func fixedZone(sign, hour, minute string) *time.Location {
+	hours, _ := strconv.Atoi(hour)
+	minutes, _ := strconv.Atoi(minute)
+	offset := hours*3600 + minutes*60
+	if sign == "-" {
+		offset = -offset
+	}
+	return time.FixedZone("", offset)
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+// fieldParser parse a field from the scanner
+type fieldParser func(s *formatScanner, f *dateFields) bool
+
+// parse day of the month
+func parseDay(s *formatScanner, f *dateFields) bool {
+	day, _, ok := s.number(1, 2)
+	f.day = day
+	f.flags |= hasDay
+	return ok
+}
+
+// parse textual weekday, short or full name
+func parseWeekdayName(s *formatScanner, f *dateFields) bool {
+	weekday, ok := s.name(weekdayFullNames, weekdayShortNames)
+	f.weekday = weekday
+	f.flags |= hasWeekday
+	return ok
+}
+
+// parse textual month, short or full name
+func parseMonthName(s *formatScanner, f *dateFields) bool {
+	month, ok := s.name(monthFullNames, monthShortNames)
+	f.month = month + 1
+	f.flags |= hasMonth
+	return ok
+}
+
+// parse numeric month
+func parseMonth(s *formatScanner, f *dateFields) bool {
+	month, _, ok := s.number(1, 2)
+	f.month = month
+	f.flags |= hasMonth
+	return ok
+}
+
+// parse full year
+func parseYear(s *formatScanner, f *dateFields) bool {
+	year, _, ok := s.number(1, 4)
+	f.year = year
+	f.flags |= hasYear
+	return ok
+}
+
+// parse two digits year, 70-99 => 1970-1999, 00-69 => 2000-2069
+func parseShortYear(s *formatScanner, f *dateFields) bool {
+	year, _, ok := s.number(2, 2)
+	if year < 70 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+	f.year = year
+	f.flags |= hasYear
+	return ok
+}
+
+// parse hour, 12-hour or 24-hour
+func parseHour(s *formatScanner, f *dateFields) bool {
+	hour, _, ok := s.number(1, 2)
+	f.hour = hour
+	f.flags |= hasHour
+	return ok
+}
+
+// parse the ordinal suffix of the day, "st", "nd", "rd" or "th" in english,
+// the suffix of the locale is the same as 'DateFormat', e.g. "er" of "1er" in french, and may be empty
+func parseOrdinal(s *formatScanner, f *dateFields) bool {
+	rest := s.rest()
+	if s.locale != nil && s.locale.Name != "en" {
+		if s.locale.Ordinal == nil {
+			return true
+		}
+		days := []int{f.day}
+		if f.flags&hasDay == 0 {
+			days = days[:0]
+			for day := 1; day <= 31; day++ {
+				days = append(days, day)
+			}
+		}
+		// the longest suffix of the days
+		size := 0
+		for _, day := range days {
+			suffix := s.locale.Ordinal(day)
+			if len(suffix) > size && strings.HasPrefix(rest, suffix) {
+				size = len(suffix)
+			}
+		}
+		s.pos += size
+		return true
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if len(rest) >= 2 && strings.EqualFold(rest[:2], suffix) {
+			s.pos += 2
+			return true
+		}
+	}
+	return false
+}
+
+// parse ante meridiem and post meridiem
+func parseMeridian(s *formatScanner, f *dateFields) bool {
+	rest := strings.ToLower(s.rest())
+	if strings.HasPrefix(rest, "am") || strings.HasPrefix(rest, "pm") {
+		f.pm = rest[0] == 'p'
+		f.flags |= hasMeridian
+		s.pos += 2
+		return true
+	}
+	return false
+}
+
+// parse fraction seconds with 'max' digits
+func parseFraction(max int) fieldParser {
+	return func(s *formatScanner, f *dateFields) bool {
+		frac, count, ok := s.number(1, max)
+		f.nanosecond = frac * int(math.Pow10(9-count))
+		f.flags |= hasFraction
+		return ok
+	}
+}
+
+// parse timezone
+func parseZone(s *formatScanner, f *dateFields) bool {
+	location, ok := s.zone()
+	f.location = location
+	f.flags |= hasZone
+	return ok
+}
+
+// the seconds from the year 1 to the unix epoch, the same as the time package
+const unixToInternal = (1969*365 + 1969/4 - 1969/100 + 1969/400) * 24 * 3600
+
+// parse seconds since the unix epoch
+// all the fields will be set by the timestamp in UTC
+func parseTimestamp(s *formatScanner, f *dateFields) bool {
+	multi := 1
+	if rest := s.rest(); rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		if rest[0] == '-' {
+			multi = -1
+		}
+		s.pos++
+	}
+	timestamp, _, ok := s.number(1, 19)
+	if !ok {
+		return false
+	}
+	// the internal seconds of time.Time start from the year 1, so the max unix seconds are less than math.MaxInt64
+	if timestamp > math.MaxInt64-unixToInternal {
+		return false
+	}
+	t := time.Unix(int64(timestamp*multi), 0).UTC()
+	*f = dateFields{
+		year:     t.Year(),
+		month:    int(t.Month()),
+		day:      t.Day(),
+		hour:     t.Hour(),
+		minute:   t.Minute(),
+		second:   t.Second(),
+		location: time.UTC,
+		flags:    hasYear | hasMonth | hasDay | timeFieldFlags | hasZone,
+	}
+	return true
+}
+
+// set an integer field with the flag
+func parseNumberField(min, max int, flag fieldFlag, field func(f *dateFields) *int) fieldParser {
+	return func(s *formatScanner, f *dateFields) bool {
+		num, _, ok := s.number(min, max)
+		*field(f) = num
+		f.flags |= flag
+		return ok
+	}
+}
+
+var (
+	// month names
+	monthFullNames  = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	monthShortNames = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	// the field parsers of the format characters
+	// they are the same characters as 'DateFormat'
+	formatParsers = map[byte]fieldParser{
+		// day
+		'd': parseDay,
+		'j': parseDay,
+		'D': parseWeekdayName,
+		'l': parseWeekdayName,
+		'S': parseOrdinal,
+		// the same as 'DateFormat', 'N' is golang's weekday
+		'N': parseNumberField(1, 1, hasWeekday, func(f *dateFields) *int { return &f.weekday }),
+		// the same as 'DateFormat', 'w' is 1 for sunday, 0 for saturday
+		'w': func(s *formatScanner, f *dateFields) bool {
+			weekday, _, ok := s.number(1, 1)
+			f.weekday = (weekday + 6) % 7
+			f.flags |= hasWeekday
+			return ok
+		},
+		'z': parseNumberField(1, 3, hasYearDay, func(f *dateFields) *int { return &f.yearDay }),
+		// week
+		'W': parseNumberField(1, 2, hasWeek, func(f *dateFields) *int { return &f.week }),
+		// month
+		'F': parseMonthName,
+		'M': parseMonthName,
+		'm': parseMonth,
+		'n': parseMonth,
+		't': parseNumberField(2, 2, hasMonthDays, func(f *dateFields) *int { return &f.monthDays }),
+		// year
+		'L': func(s *formatScanner, f *dateFields) bool {
+			leap, _, ok := s.number(1, 1)
+			f.leap = leap == 1
+			f.flags |= hasLeap
+			return ok && leap <= 1
+		},
+		'Y': parseYear,
+		'y': parseShortYear,
+		// time
+		'a': parseMeridian,
+		'A': parseMeridian,
+		'g': parseHour,
+		'h': parseHour,
+		'G': parseHour,
+		'H': parseHour,
+		'i': parseNumberField(2, 2, hasMinute, func(f *dateFields) *int { return &f.minute }),
+		's': parseNumberField(2, 2, hasSecond, func(f *dateFields) *int { return &f.second }),
+		'u': parseFraction(6),
+		'v': parseFraction(3),
+		// timezone
+		'e': parseZone,
+		'T': parseZone,
+		'O': parseZone,
+		'P': parseZone,
+		// timestamp
+		'U': parseTimestamp,
+	}
+)
+
+// ParseFormat parse the value by the format, the same as php's 'DateTime::createFromFormat'
+// the format characters are the same as 'DateFormat', and the modifiers:
+// '!' reset all fields to the unix epoch
+// '|' reset the fields not parsed yet to the unix epoch
+// '+' ignore the trailing data, the format characters after it are still parsed
+// '*' random characters until the next separator or digit
+// '?' a random character
+// '#' one of the separators ";:/.,-()"
+// '\' escape the next character
+// the ordinal suffixes of 'S' are the ones of the locale in the options, e.g. "1er" with 'WithLocale("fr")'
+func ParseFormat(format, value string, opts ...Option) (time.Time, error) {
+	o := makeOptions(opts)
+	fields := dateFields{}
+	s := &formatScanner{value: value, locale: o.locale}
+	allowTrailing := false
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		if parser, ok := formatParsers[ch]; ok {
+			if !parser(s, &fields) {
+				return time.Time{}, fmt.Errorf("wrong value for the format character '%c' at position %d: '%s'", ch, s.pos, value)
+			}
+			continue
+		}
+		switch ch {
+		case '!':
+			fields.reset(false)
+		case '|':
+			fields.reset(true)
+		case '+':
+			allowTrailing = true
+		case ' ':
+			// zero or more whitespaces
+			for !s.done() && (s.value[s.pos] == ' ' || s.value[s.pos] == '\t') {
+				s.pos++
+			}
+		case '*':
+			for !s.done() && !isDigit(s.value[s.pos]) && !strings.ContainsRune(" "+formatSeparators, rune(s.value[s.pos])) {
+				s.pos++
+			}
+		case '?':
+			if s.done() {
+				return time.Time{}, fmt.Errorf("unexpected data end for the format character '?': '%s'", value)
+			}
+			_, size := utf8.DecodeRuneInString(s.rest())
+			s.pos += size
+		case '#':
+			if s.done() || !strings.ContainsRune(formatSeparators, rune(s.value[s.pos])) {
+				return time.Time{}, fmt.Errorf("wrong separator at position %d: '%s'", s.pos, value)
+			}
+			s.pos++
+		default:
+			if ch == '\\' && i+1 < len(format) {
+				i++
+				ch = format[i]
+			}
+			if s.done() || s.value[s.pos] != ch {
+				return time.Time{}, fmt.Errorf("the character at position %d doesn't match '%c': '%s'", s.pos, ch, value)
+			}
+			s.pos++
+		}
+	}
+	if !s.done() && !allowTrailing {
+		return time.Time{}, fmt.Errorf("trailing data '%s': '%s'", s.rest(), value)
+	}
+	lastTime, err := fields.toTime(o.location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return lastTime.In(o.location), nil
+}
@@ -0,0 +1,208 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	// full date time
+	if date, err := ParseFormat("Y-m-d H:i:s", "2021-09-05 18:07:06"); err == nil {
+		assert.True(t, isSameDate(&date, YMDHis))
+		assert.Equal(t, date.Location(), time.Local)
+	} else {
+		assert.Fail(t, "ParseFormat 'Y-m-d H:i:s' fail")
+	}
+	// textual month and 12-hour
+	if date, err := ParseFormat("d/M/Y g:i A", "05/Sep/2021 6:07 PM"); err == nil {
+		assert.True(t, isSameDate(&date, YMD|HOUR|MINUTE))
+		assert.Equal(t, date.Second(), 0)
+	} else {
+		assert.Fail(t, "ParseFormat 'd/M/Y g:i A' fail")
+	}
+	// 12am is midnight
+	if date, err := ParseFormat("Y-m-d h:ia", "2021-09-05 12:30am"); err == nil {
+		assert.Equal(t, date.Hour(), 0)
+		assert.Equal(t, date.Minute(), 30)
+	} else {
+		assert.Fail(t, "ParseFormat 'Y-m-d h:ia' fail")
+	}
+	// full names
+	if date, err := ParseFormat("l, F j, Y", "Sunday, September 5, 2021"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat 'l, F j, Y' fail")
+	}
+	// the ordinal suffixes
+	for value, day := range map[string]int{"1st September 2021": 1, "22nd September 2021": 22, "5TH September 2021": 5} {
+		if date, err := ParseFormat("jS F Y", value); err == nil {
+			assert.Equal(t, date.Day(), day, value)
+		} else {
+			assert.Fail(t, "ParseFormat 'jS F Y' '"+value+"' fail")
+		}
+	}
+	if _, err := ParseFormat("jS F Y", "5 September 2021"); err == nil {
+		assert.Fail(t, "ParseFormat 'S' without the suffix ok")
+	}
+	// the ordinal suffixes of the locales, the same as 'DateFormat'
+	for _, locale := range []string{"en", "fr", "es", "nl", "de", "ru"} {
+		for _, day := range []int{1, 2, 22} {
+			date := time.Date(2021, time.September, day, 0, 0, 0, 0, time.UTC)
+			value, _ := DateFormatLocale(date, "jS/m/Y", locale)
+			if parsed, err := ParseFormat("jS/m/Y", value, WithLocation(time.UTC), WithLocale(locale)); err == nil {
+				assert.Equal(t, parsed.Format("2006-01-02"), date.Format("2006-01-02"), value)
+			} else {
+				assert.Fail(t, "ParseFormat 'jS/m/Y' '"+value+"' with '"+locale+"' fail")
+			}
+		}
+	}
+	// weekday move the date forward
+	if date, err := ParseFormat("D Y-m-d", "Mon 2021-09-05"); err == nil {
+		assert.Equal(t, date.Day(), 6)
+	} else {
+		assert.Fail(t, "ParseFormat 'D Y-m-d' fail")
+	}
+	// compact digits and fraction
+	if date, err := ParseFormat("YmdHis.u", "20210905180706.012345"); err == nil {
+		assert.True(t, isSameDate(&date, YMDHis))
+		assert.Equal(t, date.Nanosecond(), 12345000)
+	} else {
+		assert.Fail(t, "ParseFormat 'YmdHis.u' fail")
+	}
+	// short year
+	if date, err := ParseFormat("y-n-j", "21-9-5"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat 'y-n-j' fail")
+	}
+	if date, err := ParseFormat("y", "78"); err == nil {
+		assert.Equal(t, date.Year(), 1978)
+	} else {
+		assert.Fail(t, "ParseFormat 'y' fail")
+	}
+	// day of the year
+	if date, err := ParseFormat("Y z", "2021 247"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat 'Y z' fail")
+	}
+	// iso week
+	if date, err := ParseFormat("Y W N", "2021 35 0"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat 'Y W N' fail")
+	}
+	// timezone
+	if date, err := ParseFormat("Y-m-d H:i:s O", "2021-09-05 18:07:06 +0800"); err == nil {
+		assert.Equal(t, date.Unix(), int64(1630836426))
+	} else {
+		assert.Fail(t, "ParseFormat 'O' fail")
+	}
+	if date, err := ParseFormat("Y-m-d\\TH:i:sP", "2021-09-05T10:07:06Z"); err == nil {
+		assert.Equal(t, date.Unix(), int64(1630836426))
+	} else {
+		assert.Fail(t, "ParseFormat 'P' fail")
+	}
+	if date, err := ParseFormat("Y-m-d H:i:s e", "2021-09-05 18:07:06 Asia/Shanghai"); err == nil {
+		assert.Equal(t, date.Unix(), int64(1630836426))
+	} else {
+		assert.Fail(t, "ParseFormat 'e' fail")
+	}
+	// timestamp
+	if date, err := ParseFormat("U", "1630836426"); err == nil {
+		assert.Equal(t, date.Unix(), int64(1630836426))
+	} else {
+		assert.Fail(t, "ParseFormat 'U' fail")
+	}
+	// the timestamps out of the range
+	for _, value := range []string{"9999999999999999999", "9223372036854775807", "-9999999999999999999"} {
+		if _, err := ParseFormat("U", value); err == nil {
+			assert.Fail(t, "ParseFormat 'U' out of range '"+value+"' ok")
+		}
+	}
+	// location option
+	if date, err := ParseFormat("Y-m-d H:i", "2021-09-05 18:07", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, date.Location(), time.UTC)
+		assert.Equal(t, date.Hour(), 18)
+	} else {
+		assert.Fail(t, "ParseFormat with location fail")
+	}
+	// leap and days of month
+	if _, err := ParseFormat("Y-m t L", "2020-02 29 1"); err != nil {
+		assert.Fail(t, "ParseFormat 't L' fail")
+	}
+	if _, err := ParseFormat("Y-m t", "2021-02 29"); err == nil {
+		assert.Fail(t, "ParseFormat wrong 't' ok")
+	}
+	// wrong values
+	if _, err := ParseFormat("Y-m-d", "2021/09/05"); err == nil {
+		assert.Fail(t, "ParseFormat wrong separator ok")
+	}
+	if _, err := ParseFormat("Y-m-d", "2021-09-05 18:07"); err == nil {
+		assert.Fail(t, "ParseFormat trailing data ok")
+	}
+	if _, err := ParseFormat("H:i", "18:7"); err == nil {
+		assert.Fail(t, "ParseFormat one digit minute ok")
+	}
+	if _, err := ParseFormat("g A", "13 PM"); err == nil {
+		assert.Fail(t, "ParseFormat wrong 12-hour ok")
+	}
+}
+
+func TestParseFormatModifiers(t *testing.T) {
+	// '!' reset all the fields
+	if date, err := ParseFormat("!d", "15"); err == nil {
+		assert.Equal(t, date.Format("2006-01-02 15:04:05.000"), "1970-01-15 00:00:00.000")
+	} else {
+		assert.Fail(t, "ParseFormat '!' fail")
+	}
+	// '|' reset the fields not parsed
+	if date, err := ParseFormat("Y-m-d|", "2021-09-05"); err == nil {
+		assert.Equal(t, date.Format("2006-01-02 15:04:05.000"), "2021-09-05 00:00:00.000")
+	} else {
+		assert.Fail(t, "ParseFormat '|' fail")
+	}
+	// time fields not parsed are zero
+	if date, err := ParseFormat("Y-m-d H", "2021-09-05 18"); err == nil {
+		assert.Equal(t, date.Format("15:04:05.000"), "18:00:00.000")
+	} else {
+		assert.Fail(t, "ParseFormat 'H' fail")
+	}
+	// '+' ignore trailing data
+	if date, err := ParseFormat("Y-m-d+", "2021-09-05 18:07:06"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat '+' fail")
+	}
+	// the format characters after '+' are still parsed
+	for _, value := range []string{"2021-09-05 18:07", "2021-09-05 18:07:06 trailing"} {
+		if date, err := ParseFormat("Y-m-d+ H:i", value, WithLocation(time.UTC)); err == nil {
+			assert.Equal(t, date.Format("2006-01-02 15:04"), "2021-09-05 18:07")
+		} else {
+			assert.Fail(t, "ParseFormat '+' with the format after fail")
+		}
+	}
+	// '*' skip until separator or digit
+	if date, err := ParseFormat("Y-m-d *, H:i", "2021-09-05 Sunday, 18:07"); err == nil {
+		assert.True(t, isSameDate(&date, YMD|HOUR|MINUTE))
+	} else {
+		assert.Fail(t, "ParseFormat '*' fail")
+	}
+	// '?' any character, '#' any separator
+	if date, err := ParseFormat("Y?m#d", "2021年09/05"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat '?#' fail")
+	}
+	if _, err := ParseFormat("Y#m", "2021x09"); err == nil {
+		assert.Fail(t, "ParseFormat wrong '#' ok")
+	}
+	// '\' escape
+	if date, err := ParseFormat("\\Y\\m Y-m-d", "Ym 2021-09-05"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "ParseFormat '\\' fail")
+	}
+}
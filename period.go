@@ -0,0 +1,263 @@
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period the occurrences of an ISO 8601 time interval, the same as php's DatePeriod
+// the occurrences are 'Start', 'Start' + 'Interval', 'Start' + 2 * 'Interval' ...
+type Period struct {
+	Start time.Time
+	// the interval between two occurrences
+	Interval *Interval
+	// Recurrences the count of the occurrences after the start time, -1 means unbounded
+	Recurrences int
+	// End the occurrences must be before the end time, the zero time means no end time
+	End time.Time
+	// ExcludeStart don't iterate the start time, the first occurrence of a relative step may be after the start time
+	ExcludeStart bool
+	// IncludeEnd iterate the end time too if it's an occurrence
+	IncludeEnd bool
+	// the relative step anchored to the days of months or the weekdays
+	step *relativeStep
+}
+
+// NewPeriod make a period from the start time to the end time, the end time is excluded by default
+// the start and end can be any type 'DateTime' accepted
+// the step can be an '*Interval' or a relative string, e.g. "+10 days", "every 2nd tuesday", "every last day of month"
+func NewPeriod(start, step, end interface{}, opts ...Option) (*Period, error) {
+	period, err := makePeriod(start, step, opts)
+	if err != nil {
+		return nil, err
+	}
+	if period.End, err = DateTime(end, opts...); err != nil {
+		return nil, err
+	}
+	period.Recurrences = -1
+	return period, nil
+}
+
+// NewRecurrence make a period with the count of the recurrences after the first occurrence
+// the arguments are the same as 'NewPeriod'
+func NewRecurrence(start, step interface{}, recurrences int, opts ...Option) (*Period, error) {
+	if recurrences < 0 {
+		return nil, fmt.Errorf("the recurrences can't be negative:%d", recurrences)
+	}
+	period, err := makePeriod(start, step, opts)
+	if err != nil {
+		return nil, err
+	}
+	period.Recurrences = recurrences
+	return period, nil
+}
+
+// make a period with the start time and the step
+func makePeriod(start, step interface{}, opts []Option) (*Period, error) {
+	startTime, err := DateTime(start, opts...)
+	if err != nil {
+		return nil, err
+	}
+	period := &Period{
+		Start: startTime,
+	}
+	switch s := step.(type) {
+	case string:
+		if period.Interval, period.step, err = parseRelativeStep(s); err != nil {
+			return nil, err
+		}
+	case *Interval:
+		period.Interval = s
+	case Interval:
+		period.Interval = &s
+	default:
+		return nil, fmt.Errorf("can't use the step of the period: %#v", step)
+	}
+	if period.Interval == nil || period.Interval.Invert || period.Interval.isZero() {
+		return nil, fmt.Errorf("the step of the period must be positive:%v", step)
+	}
+	return period, nil
+}
+
+// ParseInterval parse the ISO 8601 time interval, the start and end times can be any string 'DateTime' accepted
+// "start/end", "start/duration", "duration/end", e.g. "2021-09-05T00:00:00Z/P1W"
+// the repeating intervals "R<n>/start/duration", "R<n>/start/end", "R<n>/duration/end", e.g. "R5/2021-09-05T00:00:00Z/P1W"
+// the repeating intervals have n recurrences after the start time, "R/" means unbounded
+// the single intervals iterate both the start and end times
+func ParseInterval(value string, opts ...Option) (*Period, error) {
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	period := &Period{
+		Recurrences: 1,
+	}
+	isRepeating := strings.HasPrefix(parts[0], "R")
+	if isRepeating {
+		if parts[0] == "R" {
+			period.Recurrences = -1
+		} else if recurrences, err := strconv.Atoi(parts[0][1:]); err == nil && recurrences >= 0 {
+			period.Recurrences = recurrences
+		} else {
+			return nil, fmt.Errorf("wrong recurrences of the interval:'%s'", value)
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("wrong ISO 8601 interval:'%s'", value)
+	}
+	var (
+		times     [2]time.Time
+		durations [2]*Interval
+	)
+	for index, part := range parts {
+		var err error
+		if strings.HasPrefix(part, "P") {
+			if durations[index], err = ParseISODuration(part); err == nil && durations[index].Invert {
+				err = fmt.Errorf("the duration of the interval can't be negative:'%s'", part)
+			}
+		} else {
+			times[index], err = DateTime(part, opts...)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case durations[0] != nil && durations[1] != nil:
+		return nil, fmt.Errorf("the interval can't be two durations:'%s'", value)
+	case durations[1] != nil:
+		// start/duration
+		period.Start = times[0]
+		period.Interval = durations[1]
+	case durations[0] != nil:
+		// duration/end, count back from the end time
+		if period.Recurrences < 0 {
+			return nil, fmt.Errorf("the unbounded interval must have a start time:'%s'", value)
+		}
+		period.Interval = durations[0]
+		period.Start = durations[0].multiply(-period.Recurrences).AddTo(times[1])
+	default:
+		// start/end
+		if times[1].Before(times[0]) {
+			return nil, fmt.Errorf("the end time of the interval is before the start time:'%s'", value)
+		}
+		period.Start = times[0]
+		period.Interval = intervalBetween(times[0], times[1])
+	}
+	if !isRepeating {
+		// a single interval, the end of the interval is the only recurrence and it's iterated too
+		if durations[1] != nil {
+			period.End = period.occurrence(1)
+		} else {
+			period.End = times[1]
+		}
+		period.IncludeEnd = true
+	}
+	return period, nil
+}
+
+// get the interval of the occurrences from the start to the end, 'diffTime' borrows the days from the month of the start
+// but 'AddTo' clamps the days, so the days are fixed to reach the end, e.g. "2021-01-30/2021-03-01" is "P1M1D"
+// and the clock is fixed by the daylight saving time
+func intervalBetween(start, end time.Time) *Interval {
+	interval := diffTime(start, end)
+	if next := interval.AddTo(start); !next.Equal(end) {
+		interval.Days += daysBetween(next, end.In(next.Location()))
+	}
+	// the elapsed clock is changed by the daylight saving time
+	if rest := end.Sub(interval.AddTo(start)); rest != 0 && interval.clock()+rest >= 0 {
+		clock := interval.clock() + rest
+		interval.Hours = int(clock / time.Hour)
+		interval.Minutes = int(clock % time.Hour / time.Minute)
+		interval.Seconds = int(clock % time.Minute / time.Second)
+		interval.Nanoseconds = int(clock % time.Second)
+	}
+	return interval
+}
+
+// get the nth occurrence, always add to the start time so the days of months will not drift
+func (period *Period) occurrence(index int) time.Time {
+	if period.step != nil {
+		return period.step.occurrence(period.Start, index)
+	}
+	return period.Interval.multiply(index).AddTo(period.Start)
+}
+
+// Iterator get an iterator of the occurrences
+func (period *Period) Iterator() *PeriodIterator {
+	return &PeriodIterator{
+		period: period,
+	}
+}
+
+// Each call the function with the occurrences in order, stop when the function returns false
+func (period *Period) Each(fn func(t time.Time) bool) {
+	for it := period.Iterator(); it.Next(); {
+		if !fn(it.Time()) {
+			break
+		}
+	}
+}
+
+// PeriodIterator iterate the occurrences of a period lazily
+type PeriodIterator struct {
+	period  *Period
+	index   int
+	current time.Time
+	done    bool
+}
+
+// Next move to the next occurrence, return false if there are no more occurrences
+func (it *PeriodIterator) Next() bool {
+	period := it.period
+	for !it.done {
+		index := it.index
+		it.index++
+		// a zero interval has only the start time
+		if (period.Recurrences >= 0 && index > period.Recurrences) || (index > 0 && period.Interval.isZero()) {
+			it.done = true
+			break
+		}
+		cur := period.occurrence(index)
+		if !period.End.IsZero() && (cur.After(period.End) || (cur.Equal(period.End) && !period.IncludeEnd)) {
+			it.done = true
+			break
+		}
+		if index == 0 && period.ExcludeStart && cur.Equal(period.Start) {
+			continue
+		}
+		it.current = cur
+		return true
+	}
+	return false
+}
+
+// Time get the current occurrence
+func (it *PeriodIterator) Time() time.Time {
+	return it.current
+}
+
+// multiply the interval, a negative times will invert the interval
+func (interval *Interval) multiply(times int) *Interval {
+	invert := interval.Invert
+	if times < 0 {
+		times, invert = -times, !invert
+	}
+	return &Interval{
+		Years:       interval.Years * times,
+		Months:      interval.Months * times,
+		Days:        interval.Days * times,
+		Hours:       interval.Hours * times,
+		Minutes:     interval.Minutes * times,
+		Seconds:     interval.Seconds * times,
+		Nanoseconds: interval.Nanoseconds * times,
+		Invert:      invert,
+		TotalDays:   -1,
+	}
+}
+
+// check if the interval is zero
+func (interval *Interval) isZero() bool {
+	return interval.Years == 0 && interval.Months == 0 && interval.Days == 0 &&
+		interval.Hours == 0 && interval.Minutes == 0 && interval.Seconds == 0 && interval.Nanoseconds == 0
+}
@@ -0,0 +1,178 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collect the occurrences of the period as formatted strings
+func periodTimes(period *Period, layout string) []string {
+	result := []string{}
+	period.Each(func(t time.Time) bool {
+		result = append(result, t.Format(layout))
+		return len(result) < 100
+	})
+	return result
+}
+
+func TestParseInterval(t *testing.T) {
+	// repeating start/duration
+	if period, err := ParseInterval("R5/2021-09-05T00:00:00Z/P1W", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, period.Recurrences, 5)
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{
+			"2021-09-05", "2021-09-12", "2021-09-19", "2021-09-26", "2021-10-03", "2021-10-10",
+		})
+		// exclude the start time and stop before the end time
+		period.ExcludeStart = true
+		period.End = time.Date(2021, time.October, 3, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-09-12", "2021-09-19", "2021-09-26"})
+		period.IncludeEnd = true
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-09-12", "2021-09-19", "2021-09-26", "2021-10-03"})
+	} else {
+		assert.Fail(t, "ParseInterval R5/2021-09-05T00:00:00Z/P1W fail")
+	}
+	// the days of months will not drift
+	if period, err := ParseInterval("R3/2021-01-31/P1M", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-01-31", "2021-02-28", "2021-03-31", "2021-04-30"})
+	} else {
+		assert.Fail(t, "ParseInterval R3/2021-01-31/P1M fail")
+	}
+	// repeating start/end
+	if period, err := ParseInterval("R2/2021-09-05 08:00/2021-09-05 09:30", WithLocation(localLocation)); err == nil {
+		assert.Equal(t, period.Interval.String(), "PT1H30M")
+		assert.Equal(t, periodTimes(period, "15:04 -0700"), []string{"08:00 +0800", "09:30 +0800", "11:00 +0800"})
+	} else {
+		assert.Fail(t, "ParseInterval R2 start/end fail")
+	}
+	// repeating duration/end
+	if period, err := ParseInterval("R2/PT12H/2021-09-05T00:00:00Z", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, periodTimes(period, "01-02 15"), []string{"09-04 00", "09-04 12", "09-05 00"})
+	} else {
+		assert.Fail(t, "ParseInterval R2 duration/end fail")
+	}
+	// unbounded
+	if period, err := ParseInterval("R/2021-09-05/P1D"); err == nil {
+		assert.Equal(t, period.Recurrences, -1)
+		count := 0
+		for it := period.Iterator(); it.Next() && count < 1000; {
+			count++
+		}
+		assert.Equal(t, count, 1000)
+	} else {
+		assert.Fail(t, "ParseInterval unbounded fail")
+	}
+	// single intervals
+	if period, err := ParseInterval("2021-09-05T00:00:00Z/P1DT12H", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, period.End.Format("2006-01-02 15"), "2021-09-06 12")
+		assert.True(t, period.IncludeEnd)
+		assert.Equal(t, periodTimes(period, "2006-01-02 15"), []string{"2021-09-05 00", "2021-09-06 12"})
+		period.IncludeEnd = false
+		assert.Equal(t, periodTimes(period, "2006-01-02 15"), []string{"2021-09-05 00"})
+	} else {
+		assert.Fail(t, "ParseInterval start/duration fail")
+	}
+	if period, err := ParseInterval("P1M/2021-09-05", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, period.Start.Format("2006-01-02"), "2021-08-05")
+	} else {
+		assert.Fail(t, "ParseInterval duration/end fail")
+	}
+	if period, err := ParseInterval("2021-09-05/2021-10-01", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, period.Interval.String(), "P26D")
+		assert.Equal(t, period.End.Format("2006-01-02"), "2021-10-01")
+	} else {
+		assert.Fail(t, "ParseInterval start/end fail")
+	}
+	if period, err := ParseInterval("2021-09-01/2021-09-05", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-09-01", "2021-09-05"})
+	} else {
+		assert.Fail(t, "ParseInterval start/end times fail")
+	}
+	// the end of the months, the days borrowed from january are fixed
+	if period, err := ParseInterval("2021-01-30/2021-03-01", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, period.End.Format("2006-01-02"), "2021-03-01")
+		assert.Equal(t, period.Interval.String(), "P1M1D")
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-01-30", "2021-03-01"})
+	} else {
+		assert.Fail(t, "ParseInterval start/end of the months fail")
+	}
+	if period, err := ParseInterval("R2/2021-01-31/2021-03-01", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-01-31", "2021-03-01", "2021-04-02"})
+	} else {
+		assert.Fail(t, "ParseInterval repeating start/end of the months fail")
+	}
+	// wrong intervals
+	for _, value := range []string{
+		"2021-09-05", "Rx/2021-09-05/P1D", "R-1/2021-09-05/P1D", "P1D/P1W", "R/P1D/2021-09-05",
+		"2021-09-05/-P1D", "2021-09-05/2021-09-01", "R2/2021-09-05/P1D/P1D", "wrong/P1D",
+	} {
+		if _, err := ParseInterval(value); err == nil {
+			assert.Fail(t, "ParseInterval wrong interval '"+value+"' ok")
+		}
+	}
+}
+
+func TestNewPeriod(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	// month end will not drift
+	if period, err := NewRecurrence("2021-01-31", "+1 month", 4, utc); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-01-31", "2021-02-28", "2021-03-31", "2021-04-30", "2021-05-31"})
+	} else {
+		assert.Fail(t, "NewRecurrence +1 month fail")
+	}
+	if period, err := NewPeriod("2021-01-15 10:00", "every last day of month", "2021-05-01", utc); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02 15:04"), []string{
+			"2021-01-31 10:00", "2021-02-28 10:00", "2021-03-31 10:00", "2021-04-30 10:00",
+		})
+	} else {
+		assert.Fail(t, "NewPeriod every last day of month fail")
+	}
+	if period, err := NewRecurrence("2021-09-05", "first day of the month", 2, utc); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-10-01", "2021-11-01", "2021-12-01"})
+	} else {
+		assert.Fail(t, "NewRecurrence first day of month fail")
+	}
+	// weekdays
+	if period, err := NewRecurrence("2021-09-05", "every 2nd Tuesday", 3, utc); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02 Mon"), []string{
+			"2021-09-07 Tue", "2021-09-21 Tue", "2021-10-05 Tue", "2021-10-19 Tue",
+		})
+	} else {
+		assert.Fail(t, "NewRecurrence every 2nd Tuesday fail")
+	}
+	if period, err := NewRecurrence("2021-09-01", "every second tuesday of month", 2, utc); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-09-14", "2021-10-12", "2021-11-09"})
+	} else {
+		assert.Fail(t, "NewRecurrence second tuesday of month fail")
+	}
+	if period, err := NewRecurrence("2021-09-30", "last friday of month", 1, utc); err == nil {
+		assert.Equal(t, periodTimes(period, "2006-01-02"), []string{"2021-10-29", "2021-11-26"})
+	} else {
+		assert.Fail(t, "NewRecurrence last friday of month fail")
+	}
+	// days and intervals
+	if period, err := NewPeriod("2021-09-05", "every +10 days", "2021-10-05", utc); err == nil {
+		period.ExcludeStart = true
+		assert.Equal(t, periodTimes(period, "01-02"), []string{"09-15", "09-25"})
+	} else {
+		assert.Fail(t, "NewPeriod every +10 days fail")
+	}
+	if period, err := NewRecurrence(time.Date(2021, time.September, 5, 0, 0, 0, 0, time.UTC), &Interval{Hours: 36}, 2, utc); err == nil {
+		assert.Equal(t, periodTimes(period, "01-02 15"), []string{"09-05 00", "09-06 12", "09-08 00"})
+	} else {
+		assert.Fail(t, "NewRecurrence interval fail")
+	}
+	// wrong steps
+	for _, step := range []interface{}{"-1 day", "0 days", "every last tuesday", "every day of month", 10, &Interval{}} {
+		if _, err := NewRecurrence("2021-09-05", step, 1); err == nil {
+			assert.Fail(t, "NewRecurrence wrong step ok")
+		}
+	}
+	if _, err := NewRecurrence("2021-09-05", "1 day", -1); err == nil {
+		assert.Fail(t, "NewRecurrence negative recurrences ok")
+	}
+	if _, err := NewPeriod("2021-09-05", "1 day", "wrong end"); err == nil {
+		assert.Fail(t, "NewPeriod wrong end ok")
+	}
+}
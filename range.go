@@ -0,0 +1,321 @@
+package dateutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	yearOnlyRule = regexp.MustCompile("^[0-9]{4}$")
+	// "between Monday and Friday"
+	rangeBetweenRule = regexp.MustCompile("(?i)^between[ \\t]+(.+)$")
+	rangeAndRule     = regexp.MustCompile("(?i)[ \\t]+and[ \\t]+")
+	// "from 2021-09-01 to 2021-09-05", the prefix 'from' is optional
+	rangeFromRule = regexp.MustCompile("(?i)^from[ \\t]+")
+	rangeWordRule = regexp.MustCompile("(?i)[ \\t]+(?:to|until|till|through|thru)[ \\t]+")
+	// "Sep 1 - 5", "Sep 1 – 5"
+	rangeDashRule = regexp.MustCompile("[ \\t]*[–—][ \\t]*|[ \\t]+-[ \\t]+")
+	// "Sep 1-5"
+	rangeHyphenRule = regexp.MustCompile("-")
+	rangeYearsRule  = regexp.MustCompile("^[0-9]{4}-[0-9]{4}$")
+	// the endpoint with only the day and an optional year, e.g. the "5, 2021" of "Sep 1-5, 2021"
+	rangeDayRule = regexp.MustCompile("(?i)^(3[0-1]|[0-2]?[0-9])(?:st|nd|rd|th)?(?:[ \\t]*,?[ \\t]*([0-9]{4}))?$")
+	// the endpoint with only the weekday, e.g. "monday"
+	rangeWeekdayRule = regexp.MustCompile("(?i)^" + relativeWeekdayExp + "$")
+	// the field groups of the format result
+	yearKeys    = []string{"YY", "yy", "y"}
+	monthKeys   = []string{"MM", "mm", "M", "m", "hm"}
+	dayKeys     = []string{"DD", "dd"}
+	zoneKeys    = []string{"tz", "tz_plain", "tzcorrection", "tzcorrection_plain"}
+	periodKeys  = []string{"Q", "HY"}
+	weekdayKeys = []string{"l", "D"}
+)
+
+// Range the time range from the start time to the end time, the end time is excluded
+type Range struct {
+	Start time.Time
+	End   time.Time
+	// Precision the precision of the parsed string which the range covers
+	Precision Precision
+}
+
+// Contains check if the time is in the range
+func (r *Range) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// ParseQuarterRange parse the quarter or half year into the whole range of it
+// e.g. "2021-Q3", "Q3 2021", "2021H1", "H2 2021"
+// the fiscal notations "Q3 2021", "FY2022 Q1", "FY2022" are parsed by the fiscal calendar in the options
+func ParseQuarterRange(value string, opts ...Option) (*Range, error) {
+	o := makeOptions(opts)
+	value = strings.TrimSpace(value)
+	if o.fiscal != nil {
+		if date, ok := o.fiscal.parse(value); ok {
+			precision := PrecisionYear
+			if date.Quarter > 0 {
+				precision = PrecisionQuarter
+			}
+			return &Range{
+				Start:     o.fiscal.Start(date, o.location),
+				End:       o.fiscal.End(date, o.location).Add(time.Nanosecond),
+				Precision: precision,
+			}, nil
+		}
+	}
+	pattern, result, loc := matchDateFormat(value, o.locale)
+	if pattern == nil || loc[1] != len(value) {
+		return nil, fmt.Errorf("wrong quarter or half year:'%s'", value)
+	}
+	var precision Precision
+	if noEmptyField(result, "Q") != "" {
+		precision = PrecisionQuarter
+	} else if noEmptyField(result, "HY") != "" {
+		precision = PrecisionHalfYear
+	} else {
+		return nil, fmt.Errorf("wrong quarter or half year:'%s'", value)
+	}
+	start, err := makeFormatDateTime(result, o)
+	if err != nil {
+		return nil, err
+	}
+	start, end := rangeOf(start, precision)
+	return &Range{
+		Start:     start,
+		End:       end,
+		Precision: precision,
+	}, nil
+}
+
+// ParseRange parse the datetime string into the range covered by the fields present in the string
+// e.g. "2021" is the whole year, "Sep 2021" is the whole month, "2021-09-05 10am" is the whole hour
+// the missing month and day are the first ones rather than taken from now, the range is in the location of the options
+// unlike 'DateTime', four digits are always a year rather than a time like "20:21"
+func ParseRange(value string, opts ...Option) (*Range, error) {
+	value = strings.TrimSpace(value)
+	if yearOnlyRule.MatchString(value) {
+		o := makeOptions(opts)
+		_, result, _ := matchDateFormat(value, o.locale)
+		year, err := makeFormatDateTime(result, o)
+		if err != nil {
+			return nil, err
+		}
+		start, end := rangeOf(year, PrecisionYear)
+		return &Range{
+			Start:     start,
+			End:       end,
+			Precision: PrecisionYear,
+		}, nil
+	}
+	// the missing month and day are the first ones, e.g. "Feb 2021" starts from 2021-02-01 whatever the day of now
+	result, err := Parse(value, append(append([]Option{}, opts...), withFillDate())...)
+	if err != nil {
+		return nil, err
+	}
+	if result.Precision == PrecisionUnknown {
+		return nil, fmt.Errorf("wrong range:'%s'", value)
+	}
+	start, end := rangeOf(result.Time, result.Precision)
+	return &Range{
+		Start:     start,
+		End:       end,
+		Precision: result.Precision,
+	}, nil
+}
+
+// get the range of the precision which the time is in, in the location of the time
+func rangeOf(t time.Time, precision Precision) (time.Time, time.Time) {
+	year, month, day := t.Date()
+	location := t.Location()
+	switch precision {
+	case PrecisionYear:
+		return startOfDay(year, time.January, 1, location), startOfDay(year+1, time.January, 1, location)
+	case PrecisionHalfYear:
+		month = (month-1)/6*6 + 1
+		return startOfDay(year, month, 1, location), startOfDay(year, month+6, 1, location)
+	case PrecisionQuarter:
+		month = (month-1)/3*3 + 1
+		return startOfDay(year, month, 1, location), startOfDay(year, month+3, 1, location)
+	case PrecisionMonth:
+		return startOfDay(year, month, 1, location), startOfDay(year, month+1, 1, location)
+	case PrecisionDay:
+		return startOfDay(year, month, day, location), startOfDay(year, month, day+1, location)
+	}
+	// move back the smaller fields rather than rebuild the time, keep the right offset in the repeated hour
+	start := t.Add(-time.Duration(t.Nanosecond()))
+	unit := time.Second
+	switch precision {
+	case PrecisionHour:
+		start = start.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		unit = time.Hour
+	case PrecisionMinute:
+		start = start.Add(-time.Duration(t.Second()) * time.Second)
+		unit = time.Minute
+	case PrecisionFraction:
+		start, unit = t, time.Nanosecond
+	}
+	return start, start.Add(unit)
+}
+
+// ParseDateRange parse the range expression into the range from the start of the first datetime
+// to the end of the second datetime, the precision is the smaller one of the two datetimes
+// the separators are "-", "–", "to", "until", "through" and "between ... and ...", the prefix "from" is optional
+// the fields missing in the second datetime are inherited from the first one, e.g. "Sep 1-5, 2021", "2021-09-01 10:00 to 12:00",
+// "from Monday until Friday", and the first datetime inherits the year and month from the second one, e.g. "1-5 September 2021"
+// a single datetime is parsed like 'ParseRange'
+func ParseDateRange(value string, opts ...Option) (*Range, error) {
+	o := makeOptions(opts)
+	value = strings.TrimSpace(value)
+	if matchs := rangeBetweenRule.FindStringSubmatch(value); matchs != nil {
+		if r, ok := splitDateRange(matchs[1], rangeAndRule, o); ok {
+			return r, nil
+		}
+		return nil, fmt.Errorf("wrong date range:'%s'", value)
+	}
+	expr := rangeFromRule.ReplaceAllString(value, "")
+	for _, rule := range []*regexp.Regexp{rangeWordRule, rangeDashRule} {
+		if r, ok := splitDateRange(expr, rule, o); ok {
+			return r, nil
+		}
+	}
+	// "2020-2021" is not a time like "2020-02 00:21"
+	if !rangeYearsRule.MatchString(expr) {
+		if r, err := ParseRange(value, opts...); err == nil {
+			return r, nil
+		}
+	}
+	if r, ok := splitDateRange(expr, rangeHyphenRule, o); ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("wrong date range:'%s'", value)
+}
+
+// try to split the expression by every separator of the rule until both datetimes are parsed
+func splitDateRange(expr string, separator *regexp.Regexp, o *options) (*Range, bool) {
+	for _, loc := range separator.FindAllStringIndex(expr, -1) {
+		first, ok := matchRangeEndpoint(strings.TrimSpace(expr[:loc[0]]), o)
+		if !ok {
+			continue
+		}
+		second, ok := matchRangeEndpoint(strings.TrimSpace(expr[loc[1]:]), o)
+		if !ok {
+			continue
+		}
+		if r, err := makeDateRange(first, second, o); err == nil {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// match a datetime of the range expression, allow the day only and the weekday only endpoints
+func matchRangeEndpoint(value string, o *options) (FormatResult, bool) {
+	if value == "" {
+		return nil, false
+	}
+	if yearOnlyRule.MatchString(value) {
+		return FormatResult{"YY": value}, true
+	}
+	if rangeWeekdayRule.MatchString(value) || o.locale.weekdayNum(value) >= 0 {
+		return FormatResult{"l": value}, true
+	}
+	if matchs := rangeDayRule.FindStringSubmatch(value); matchs != nil {
+		return FormatResult{"dd": matchs[1], "YY": matchs[2]}, true
+	}
+	result, _, err := matchOptionsDateTime(value, o)
+	if err != nil || isResultTimezone(result) {
+		return nil, false
+	}
+	return result, true
+}
+
+// make the range of the two datetimes, the missing fields are inherited from each other
+func makeDateRange(first, second FormatResult, o *options) (*Range, error) {
+	hasDate := func(result FormatResult) bool {
+		return noEmptyField(result, yearKeys...) != "" || noEmptyField(result, monthKeys...) != "" ||
+			noEmptyField(result, dayKeys...) != "" || noEmptyField(result, periodKeys...) != ""
+	}
+	// the first datetime inherits the year, the month and the timezone
+	if noEmptyField(first, yearKeys...) == "" && noEmptyField(first, periodKeys...) == "" {
+		copyFields(first, second, yearKeys)
+	}
+	if noEmptyField(first, dayKeys...) != "" && noEmptyField(first, monthKeys...) == "" {
+		copyFields(first, second, monthKeys)
+	}
+	if noEmptyField(first, zoneKeys...) == "" {
+		copyFields(first, second, zoneKeys)
+	}
+	if noEmptyField(second, zoneKeys...) == "" {
+		copyFields(second, first, zoneKeys)
+	}
+	firstPrecision, secondPrecision := precisionOf(first), precisionOf(second)
+	fillDateFields(first)
+	start, err := makeFormatDateTime(first, o)
+	if err != nil {
+		return nil, err
+	}
+	// the second datetime inherits the date from the first one, fill the fields by the start time if the first one has no date
+	if noEmptyField(second, periodKeys...) == "" {
+		date := FormatResult{
+			"YY": fmt.Sprintf("%04d", start.Year()),
+			"MM": fmt.Sprintf("%02d", start.Month()),
+			"DD": fmt.Sprintf("%02d", start.Day()),
+		}
+		if hasDate(first) {
+			date = first
+		}
+		hasMonth := noEmptyField(second, monthKeys...) != ""
+		if noEmptyField(second, yearKeys...) == "" {
+			copyFields(second, date, yearKeys)
+		}
+		if !hasMonth && noEmptyField(second, yearKeys...) == noEmptyField(date, yearKeys...) {
+			copyFields(second, date, monthKeys)
+			if noEmptyField(second, dayKeys...) == "" {
+				copyFields(second, date, dayKeys)
+			}
+		}
+	}
+	fillDateFields(second)
+	end, err := makeFormatDateTime(second, o)
+	if err != nil {
+		return nil, err
+	}
+	start, _ = rangeOf(start, firstPrecision)
+	_, end = rangeOf(end, secondPrecision)
+	if !start.Before(end) {
+		return nil, fmt.Errorf("the end is before the start of the range")
+	}
+	precision := firstPrecision
+	if secondPrecision > precision {
+		precision = secondPrecision
+	}
+	return &Range{
+		Start:     start,
+		End:       end,
+		Precision: precision,
+	}, nil
+}
+
+// copy the first non empty field of the keys from the source
+func copyFields(target, source FormatResult, keys []string) {
+	for _, key := range keys {
+		if value := source[key]; value != "" {
+			target[key] = value
+			return
+		}
+	}
+}
+
+// fill the missing month and day with the first ones rather than now, so the day won't overflow
+func fillDateFields(result FormatResult) {
+	if noEmptyField(result, periodKeys...) != "" || noEmptyField(result, dayKeys...) != "" {
+		return
+	}
+	if noEmptyField(result, monthKeys...) != "" {
+		result["DD"] = "01"
+	} else if noEmptyField(result, yearKeys...) != "" && noEmptyField(result, weekdayKeys...) == "" {
+		result["MM"], result["DD"] = "01", "01"
+	}
+}
@@ -0,0 +1,207 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuarterDate(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]string{
+		"2021-Q3": "2021-07-01 00:00:00",
+		"2021Q1":  "2021-01-01 00:00:00",
+		"2021 q4": "2021-10-01 00:00:00",
+		"Q3 2021": "2021-07-01 00:00:00",
+		"Q2/2021": "2021-04-01 00:00:00",
+		"2021H1":  "2021-01-01 00:00:00",
+		"2021-H2": "2021-07-01 00:00:00",
+		"H2 2021": "2021-07-01 00:00:00",
+	}
+	for value, expect := range cases {
+		if date, err := DateTime(value, utc); err == nil {
+			assert.Equal(t, date.Format("2006-01-02 15:04:05"), expect)
+		} else {
+			assert.Fail(t, "DateTime '"+value+"' fail")
+		}
+	}
+	for _, value := range []string{"2021-Q5", "Q0 2021", "2021H3"} {
+		if _, err := DateTime(value, utc); err == nil {
+			assert.Fail(t, "DateTime wrong quarter '"+value+"' ok")
+		}
+	}
+}
+
+func TestParseQuarterRange(t *testing.T) {
+	layout := "2006-01-02 15:04:05"
+	utc := WithLocation(time.UTC)
+	cases := map[string][2]string{
+		"2021-Q3": {"2021-07-01 00:00:00", "2021-10-01 00:00:00"},
+		"Q4 2021": {"2021-10-01 00:00:00", "2022-01-01 00:00:00"},
+		"2021H1":  {"2021-01-01 00:00:00", "2021-07-01 00:00:00"},
+		"H2 2021": {"2021-07-01 00:00:00", "2022-01-01 00:00:00"},
+	}
+	for value, expect := range cases {
+		if r, err := ParseQuarterRange(value, utc); err == nil {
+			assert.Equal(t, [2]string{r.Start.Format(layout), r.End.Format(layout)}, expect)
+		} else {
+			assert.Fail(t, "ParseQuarterRange '"+value+"' fail")
+		}
+	}
+	// the location
+	if r, err := ParseQuarterRange("2021-Q3", WithLocation(localLocation)); err == nil {
+		assert.Equal(t, r.Start.Format(layout+" -0700"), "2021-07-01 00:00:00 +0800")
+		assert.True(t, r.Contains(time.Date(2021, time.September, 30, 23, 59, 59, 0, localLocation)))
+		assert.False(t, r.Contains(time.Date(2021, time.October, 1, 0, 0, 0, 0, localLocation)))
+		assert.False(t, r.Contains(time.Date(2021, time.June, 30, 23, 59, 59, 0, localLocation)))
+	} else {
+		assert.Fail(t, "ParseQuarterRange with location fail")
+	}
+	// the fiscal quarters
+	fiscal := WithFiscalCalendar(&FiscalCalendar{StartMonth: time.April})
+	if r, err := ParseQuarterRange("Q3 2021", utc, fiscal); err == nil {
+		assert.Equal(t, [2]string{r.Start.Format(layout), r.End.Format(layout)}, [2]string{"2020-10-01 00:00:00", "2021-01-01 00:00:00"})
+	} else {
+		assert.Fail(t, "ParseQuarterRange fiscal quarter fail")
+	}
+	if r, err := ParseQuarterRange("FY2022", utc, fiscal); err == nil {
+		assert.Equal(t, [2]string{r.Start.Format(layout), r.End.Format(layout)}, [2]string{"2021-04-01 00:00:00", "2022-04-01 00:00:00"})
+	} else {
+		assert.Fail(t, "ParseQuarterRange fiscal year fail")
+	}
+	for _, value := range []string{"2021-09-05", "2021", "2021-Q3 10:00", "wrong"} {
+		if _, err := ParseQuarterRange(value, utc); err == nil {
+			assert.Fail(t, "ParseQuarterRange wrong quarter '"+value+"' ok")
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	layout := "2006-01-02 15:04:05"
+	utc := WithLocation(time.UTC)
+	cases := map[string][2]string{
+		"2021":                {"2021-01-01 00:00:00", "2022-01-01 00:00:00"},
+		"Sep 2021":            {"2021-09-01 00:00:00", "2021-10-01 00:00:00"},
+		"2021-09":             {"2021-09-01 00:00:00", "2021-10-01 00:00:00"},
+		"2021-09-05":          {"2021-09-05 00:00:00", "2021-09-06 00:00:00"},
+		"2021-12-31":          {"2021-12-31 00:00:00", "2022-01-01 00:00:00"},
+		"2021-09-05 10am":     {"2021-09-05 10:00:00", "2021-09-05 11:00:00"},
+		"2021-09-05 10:30":    {"2021-09-05 10:30:00", "2021-09-05 10:31:00"},
+		"2021-09-05 10:30:15": {"2021-09-05 10:30:15", "2021-09-05 10:30:16"},
+		"2021-Q3":             {"2021-07-01 00:00:00", "2021-10-01 00:00:00"},
+	}
+	for value, expect := range cases {
+		if r, err := ParseRange(value, utc); err == nil {
+			assert.Equal(t, [2]string{r.Start.Format(layout), r.End.Format(layout)}, expect)
+		} else {
+			assert.Fail(t, "ParseRange '"+value+"' fail")
+		}
+	}
+	if r, err := ParseRange("Sep 2021", utc); err == nil {
+		assert.Equal(t, r.Precision, PrecisionMonth)
+	} else {
+		assert.Fail(t, "ParseRange precision fail")
+	}
+	// the missing day is the first one whatever the day of now, e.g. "Feb 2021" on the 31st
+	for month := time.January; month <= time.December; month++ {
+		value := month.String() + " 2021"
+		if r, err := ParseRange(value, utc); err == nil {
+			assert.Equal(t, r.Start, time.Date(2021, month, 1, 0, 0, 0, 0, time.UTC))
+			assert.Equal(t, r.End, time.Date(2021, month+1, 1, 0, 0, 0, 0, time.UTC))
+		} else {
+			assert.Fail(t, "ParseRange '"+value+"' fail")
+		}
+	}
+	if result, err := Parse("Feb 2021", utc, withFillDate()); err == nil {
+		assert.Equal(t, result.Time, time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, result.Precision, PrecisionMonth)
+	} else {
+		assert.Fail(t, "Parse with the filled date fail")
+	}
+	// the location
+	if r, err := ParseRange("2021-09-05", WithLocation(localLocation)); err == nil {
+		assert.Equal(t, r.Start.Format(layout+" -0700"), "2021-09-05 00:00:00 +0800")
+		assert.Equal(t, r.End.Format(layout+" -0700"), "2021-09-06 00:00:00 +0800")
+	} else {
+		assert.Fail(t, "ParseRange with location fail")
+	}
+	// the zone in the string, the range is moved into the location of the options
+	if r, err := ParseRange("2021-09-05 10:30 +0800", utc); err == nil {
+		assert.Equal(t, [2]string{r.Start.Format(layout), r.End.Format(layout)}, [2]string{"2021-09-05 02:30:00", "2021-09-05 02:31:00"})
+	} else {
+		assert.Fail(t, "ParseRange with zone fail")
+	}
+	// the daylight saving time, the day is 23 hours
+	if newYork, err := time.LoadLocation("America/New_York"); err == nil {
+		if r, err := ParseRange("2021-03-14", WithLocation(newYork)); err == nil {
+			assert.Equal(t, r.End.Sub(r.Start), 23*time.Hour)
+		} else {
+			assert.Fail(t, "ParseRange daylight saving day fail")
+		}
+	}
+	for _, value := range []string{"wrong", "Europe/Paris", ""} {
+		if _, err := ParseRange(value, utc); err == nil {
+			assert.Fail(t, "ParseRange wrong range '"+value+"' ok")
+		}
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	layout := "2006-01-02 15:04"
+	utc := WithLocation(time.UTC)
+	cases := map[string][2]string{
+		"Sep 1-5, 2021":                      {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"Sep 1 - 5 2021":                     {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"1-5 September 2021":                 {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"2021-09-01 to 2021-09-05":           {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"2021-09-01 - 2021-09-05":            {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"2021-09-01-2021-09-05":              {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"from 2021-09-01 until 2021-09-05":   {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"between 2021-09-01 and 2021-09-05":  {"2021-09-01 00:00", "2021-09-06 00:00"},
+		"Sep 28 – Oct 3, 2021":               {"2021-09-28 00:00", "2021-10-04 00:00"},
+		"Dec 28, 2021 through Jan 3, 2022":   {"2021-12-28 00:00", "2022-01-04 00:00"},
+		"2021-09-05 10:00 to 12:00":          {"2021-09-05 10:00", "2021-09-05 12:01"},
+		"2021-09-05 10am - 2pm":              {"2021-09-05 10:00", "2021-09-05 15:00"},
+		"Sep - Oct 2021":                     {"2021-09-01 00:00", "2021-11-01 00:00"},
+		"2020-2021":                          {"2020-01-01 00:00", "2022-01-01 00:00"},
+		"Q1 2021 to Q2 2021":                 {"2021-01-01 00:00", "2021-07-01 00:00"},
+		"2021-09-05":                         {"2021-09-05 00:00", "2021-09-06 00:00"},
+		"between 2021-09-06 and Friday":      {"2021-09-06 00:00", "2021-09-11 00:00"},
+		"from 2021-09-06 10:00 until Friday": {"2021-09-06 10:00", "2021-09-11 00:00"},
+	}
+	for value, expect := range cases {
+		if r, err := ParseDateRange(value, utc); err == nil {
+			assert.Equal(t, [2]string{r.Start.Format(layout), r.End.Format(layout)}, expect, value)
+		} else {
+			assert.Fail(t, "ParseDateRange '"+value+"' fail")
+		}
+	}
+	// the weekdays from now
+	if r, err := ParseDateRange("from Monday until Friday", utc); err == nil {
+		assert.Equal(t, r.Start.Weekday(), time.Monday)
+		assert.Equal(t, r.End.Weekday(), time.Saturday)
+		assert.Equal(t, r.End.Sub(r.Start), 5*24*time.Hour)
+		assert.Equal(t, r.Precision, PrecisionDay)
+	} else {
+		assert.Fail(t, "ParseDateRange weekdays fail")
+	}
+	// the precision
+	if r, err := ParseDateRange("2021-09-01 to 2021-09-05 10:30", utc); err == nil {
+		assert.Equal(t, r.End.Format(layout), "2021-09-05 10:31")
+		assert.Equal(t, r.Precision, PrecisionMinute)
+	} else {
+		assert.Fail(t, "ParseDateRange precision fail")
+	}
+	// the location
+	if r, err := ParseDateRange("Sep 1-5, 2021", WithLocation(localLocation)); err == nil {
+		assert.Equal(t, r.Start.Format(layout+" -0700"), "2021-09-01 00:00 +0800")
+	} else {
+		assert.Fail(t, "ParseDateRange with location fail")
+	}
+	for _, value := range []string{"2021-09-05 to 2021-09-01", "between 2021-09-01", "Sep 1 to", "wrong to wrong"} {
+		if _, err := ParseDateRange(value, utc); err == nil {
+			assert.Fail(t, "ParseDateRange wrong range '"+value+"' ok")
+		}
+	}
+}
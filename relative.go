@@ -0,0 +1,201 @@
+package dateutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	relativeUnitExp    = "(sec|second|min|minute|hour|day|week|fortnight|month|year)s?"
+	relativeWeekdayExp = "(sun|mon|tue|wed|thu|fri|sat)[a-z]*"
+	relativeOrdinalExp = "(first|1st|second|2nd|third|3rd|fourth|4th|last)"
+	relativeMonthExp   = "of[ \\t]+(?:the[ \\t]+|each[ \\t]+|every[ \\t]+)?month"
+	// "+10 days", "2 weeks", "a month", "other day", "year"
+	relativeOffsetRule = regexp.MustCompile("(?i)^([+-]?[0-9]+|an?|other)?[ \\t]*" + relativeUnitExp + "$")
+	// "last day of month", "first day of each month"
+	relativeDayOfMonthRule = regexp.MustCompile("(?i)^(first|last)[ \\t]+day[ \\t]+" + relativeMonthExp + "$")
+	// "2nd tuesday of month", "last friday of the month"
+	relativeWeekdayOfMonthRule = regexp.MustCompile("(?i)^" + relativeOrdinalExp + "[ \\t]+" + relativeWeekdayExp + "[ \\t]+" + relativeMonthExp + "$")
+	// the relative offsets at the end of a datetime string, e.g. "2021-01-31 +1 month -2 days"
+	// "+5 weekdays" and "5 weekdays" move by the business days, the other units must have the sign
+	relativeSuffixRule     = regexp.MustCompile("(?i)(?:^|[ \\t]+)((?:(?:[+-][0-9]+[ \\t]*(?:weekdays?|" + relativeUnitExp + ")|[0-9]+[ \\t]*weekdays?)(?:[ \\t]+|$))+)$")
+	relativeSuffixItemRule = regexp.MustCompile("(?i)([+-]?[0-9]+)[ \\t]*(?:(weekday)s?|" + relativeUnitExp + ")")
+	// "2nd tuesday", "other friday", "monday"
+	relativeWeekdayRule = regexp.MustCompile("(?i)^(?:" + relativeOrdinalExp + "|(other))?[ \\t]*" + relativeWeekdayExp + "$")
+	// the ordinal numbers, 'last' is -1
+	relativeOrdinals = map[string]int{
+		"first": 1, "1st": 1,
+		"second": 2, "2nd": 2,
+		"third": 3, "3rd": 3,
+		"fourth": 4, "4th": 4,
+		"last": -1,
+	}
+)
+
+// relativeStep the step of a period which is anchored to the days of months or the weekdays
+type relativeStep struct {
+	// the months between two occurrences of the days of months
+	months int
+	// the weeks between two occurrences of the weekdays
+	weeks int
+	// 1 is the first day of the month, -1 is the last day
+	dayOfMonth int
+	// the ordinal of the weekday in the month, -1 is the last one, 0 means every weekday
+	ordinal int
+	weekday int
+}
+
+// parse the relative offset string, e.g. "+10 days", "-2 weeks", "a month"
+func parseRelativeOffset(value string) (*Interval, bool) {
+	matchs := relativeOffsetRule.FindStringSubmatch(strings.TrimSpace(value))
+	if matchs == nil {
+		return nil, false
+	}
+	num := 1
+	switch count := strings.ToLower(matchs[1]); count {
+	case "", "a", "an":
+	case "other":
+		num = 2
+	default:
+		num, _ = strconv.Atoi(count)
+	}
+	interval := &Interval{
+		TotalDays: -1,
+	}
+	if num < 0 {
+		num = -num
+		interval.Invert = true
+	}
+	switch strings.ToLower(matchs[2]) {
+	case "sec", "second":
+		interval.Seconds = num
+	case "min", "minute":
+		interval.Minutes = num
+	case "hour":
+		interval.Hours = num
+	case "day":
+		interval.Days = num
+	case "week":
+		interval.Days = num * 7
+	case "fortnight":
+		interval.Days = num * 14
+	case "month":
+		interval.Months = num
+	case "year":
+		interval.Years = num
+	}
+	return interval, true
+}
+
+// split the relative offsets suffix from the datetime string
+func splitRelativeSuffix(value string) (string, string, bool) {
+	if loc := relativeSuffixRule.FindStringSubmatchIndex(value); loc != nil {
+		return strings.TrimSpace(value[:loc[0]]), value[loc[2]:loc[3]], true
+	}
+	return value, "", false
+}
+
+// add the relative offsets to the time one by one
+// the months will overflow to the next month like 'AddDate' unless the option 'noOverflow' is true
+// the weekdays are the business days of the calendar in the options
+func addRelativeOffsets(t time.Time, offsets string, o *options) time.Time {
+	for _, matchs := range relativeSuffixItemRule.FindAllStringSubmatch(offsets, -1) {
+		if matchs[2] != "" {
+			days, _ := strconv.Atoi(matchs[1])
+			t = AddBusinessDays(t, days, o.calendar)
+			continue
+		}
+		interval, _ := parseRelativeOffset(matchs[0])
+		if o.noOverflow {
+			t = interval.AddTo(t)
+			continue
+		}
+		sign := 1
+		if interval.Invert {
+			sign = -1
+		}
+		t = t.AddDate(sign*interval.Years, sign*interval.Months, sign*interval.Days)
+		t = t.Add(time.Duration(sign) * interval.clock())
+	}
+	return t
+}
+
+// parse the step of a period, the prefix 'every' is optional
+// e.g. "every +10 days", "every 2nd tuesday", "every last day of month", "first monday of month"
+func parseRelativeStep(value string) (*Interval, *relativeStep, error) {
+	step := strings.TrimSpace(value)
+	if len(step) > 6 && strings.EqualFold(step[:6], "every ") {
+		step = strings.TrimSpace(step[6:])
+	}
+	if interval, ok := parseRelativeOffset(step); ok {
+		if interval.Invert || interval.isZero() {
+			return nil, nil, fmt.Errorf("the step of the period must be positive:'%s'", value)
+		}
+		return interval, nil, nil
+	}
+	monthly := &Interval{Months: 1, TotalDays: -1}
+	if matchs := relativeDayOfMonthRule.FindStringSubmatch(step); matchs != nil {
+		return monthly, &relativeStep{
+			months:     1,
+			dayOfMonth: relativeOrdinals[strings.ToLower(matchs[1])],
+		}, nil
+	}
+	if matchs := relativeWeekdayOfMonthRule.FindStringSubmatch(step); matchs != nil {
+		return monthly, &relativeStep{
+			months:  1,
+			ordinal: relativeOrdinals[strings.ToLower(matchs[1])],
+			weekday: getWeekdayNum(matchs[2]),
+		}, nil
+	}
+	if matchs := relativeWeekdayRule.FindStringSubmatch(step); matchs != nil {
+		weeks := 1
+		if matchs[2] != "" {
+			weeks = 2
+		} else if matchs[1] != "" {
+			weeks = relativeOrdinals[strings.ToLower(matchs[1])]
+		}
+		if weeks > 0 {
+			return &Interval{Days: weeks * 7, TotalDays: -1}, &relativeStep{
+				weeks:   weeks,
+				weekday: getWeekdayNum(matchs[3]),
+			}, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("wrong relative step:'%s'", value)
+}
+
+// get the nth occurrence of the step, the first occurrence is the first matched day not before the start time
+func (step *relativeStep) occurrence(start time.Time, index int) time.Time {
+	if step.weeks > 0 {
+		return forwardToWeekday(start, step.weekday).AddDate(0, 0, index*step.weeks*7)
+	}
+	year, month, _ := start.Date()
+	first := step.dayInMonth(start, year, month)
+	if first.Before(start) {
+		index++
+	}
+	total := year*12 + int(month) - 1 + index*step.months
+	return step.dayInMonth(start, floorDiv(total, 12), time.Month(floorMod(total, 12)+1))
+}
+
+// get the matched day in the month, keep the clock of the start time
+func (step *relativeStep) dayInMonth(start time.Time, year int, month time.Month) time.Time {
+	days := daysInMonth(year, month)
+	var day int
+	switch {
+	case step.dayOfMonth > 0:
+		day = step.dayOfMonth
+	case step.dayOfMonth < 0:
+		day = days
+	case step.ordinal > 0:
+		firstWeekday := int(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday())
+		day = 1 + (step.weekday-firstWeekday+7)%7 + (step.ordinal-1)*7
+	default:
+		lastWeekday := int(time.Date(year, month, days, 0, 0, 0, 0, time.UTC).Weekday())
+		day = days - (lastWeekday-step.weekday+7)%7
+	}
+	return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+}
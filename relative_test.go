@@ -0,0 +1,80 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeOffset(t *testing.T) {
+	cases := map[string]string{
+		"+10 days":    "P10D",
+		"-2 weeks":    "-P14D",
+		"a month":     "P1M",
+		"other day":   "P2D",
+		"1 fortnight": "P14D",
+		"year":        "P1Y",
+		"3 hours":     "PT3H",
+		"+90 min":     "PT90M",
+		"1 sec":       "PT1S",
+	}
+	for value, expect := range cases {
+		if interval, ok := parseRelativeOffset(value); ok {
+			assert.Equal(t, interval.String(), expect)
+		} else {
+			assert.Fail(t, "parseRelativeOffset '"+value+"' fail")
+		}
+	}
+	for _, value := range []string{"", "10", "+1 decade", "next month"} {
+		if _, ok := parseRelativeOffset(value); ok {
+			assert.Fail(t, "parseRelativeOffset wrong offset '"+value+"' ok")
+		}
+	}
+}
+
+func TestRelativeSuffix(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	// overflow like 'AddDate' by default
+	if date, err := DateTime("2021-01-31 +1 month", utc); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-03-03")
+	} else {
+		assert.Fail(t, "DateTime 2021-01-31 +1 month fail")
+	}
+	if date, err := DateTime("2021-01-31 +1 month", utc, WithNoOverflow()); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-02-28")
+	} else {
+		assert.Fail(t, "DateTime 2021-01-31 +1 month no overflow fail")
+	}
+	if date, err := DateTime("2021-09-05 18:07:06 +1 year -2 days +90 mins", utc); err == nil {
+		assert.Equal(t, date.Format("2006-01-02 15:04:05"), "2022-09-03 19:37:06")
+	} else {
+		assert.Fail(t, "DateTime multiple offsets fail")
+	}
+	if date, err := DateTime("2021-09-05T10:00:00Z -1 week"); err == nil {
+		assert.Equal(t, date.UTC().Format("2006-01-02 15:04"), "2021-08-29 10:00")
+	} else {
+		assert.Fail(t, "DateTime offset with timezone fail")
+	}
+	// the base time is now
+	if date, err := DateTime("+1 day"); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), time.Now().AddDate(0, 0, 1).Format("2006-01-02"))
+	} else {
+		assert.Fail(t, "DateTime +1 day fail")
+	}
+	// the literal dates are not clamped, only the offsets
+	if date, err := DateTime("2021-02-31", utc, WithNoOverflow()); err == nil {
+		assert.Equal(t, date.Format("2006-01-02"), "2021-03-03")
+	} else {
+		assert.Fail(t, "DateTime 2021-02-31 no overflow fail")
+	}
+	if _, err := DateTime("wrong date +1 day"); err == nil {
+		assert.Fail(t, "DateTime wrong date with offset ok")
+	}
+	// the offsets without the sign are not the relative offsets
+	for _, value := range []string{"2021-09-05 3 days", "2021-09-05 +1 day 3 days"} {
+		if _, err := DateTime(value, utc); err == nil {
+			assert.Fail(t, "DateTime '"+value+"' without the sign ok")
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package dateutil
+
+import (
+	"strings"
+	"time"
+)
+
+// Precision the smallest field which is present in the parsed string
+type Precision int
+
+const (
+	// PrecisionUnknown no date or time field, e.g. a timezone only string "Europe/Paris"
+	PrecisionUnknown Precision = iota
+	// PrecisionYear e.g. "1978"
+	PrecisionYear
+	// PrecisionHalfYear e.g. "2021H1"
+	PrecisionHalfYear
+	// PrecisionQuarter e.g. "2021-Q3"
+	PrecisionQuarter
+	// PrecisionMonth e.g. "June 2008"
+	PrecisionMonth
+	// PrecisionDay e.g. "2008-06-30"
+	PrecisionDay
+	// PrecisionHour e.g. "2008-06-30 4pm"
+	PrecisionHour
+	// PrecisionMinute e.g. "2008-06-30 16:08"
+	PrecisionMinute
+	// PrecisionSecond e.g. "2008-06-30 16:08:37"
+	PrecisionSecond
+	// PrecisionFraction e.g. "2008-06-30 16:08:37.81412"
+	PrecisionFraction
+)
+
+var precisionNames = []string{"unknown", "year", "half year", "quarter", "month", "day", "hour", "minute", "second", "fraction"}
+
+// String the name of the precision
+func (precision Precision) String() string {
+	if precision < PrecisionUnknown || int(precision) >= len(precisionNames) {
+		return precisionNames[0]
+	}
+	return precisionNames[precision]
+}
+
+// ParseResult the parsed time and the fields which are actually present in the string
+type ParseResult struct {
+	Time      time.Time
+	Precision Precision
+	// HasDate any of the year, month, day or weekday is present
+	HasDate bool
+	// HasTime any of the hour, minute, second, fraction or meridian is present
+	HasTime bool
+	// HasZone the timezone or the timezone correction is present
+	HasZone bool
+	// Rule the original rule matched the string, the date rule if the string is a datetime,
+	// "fiscal" for the fiscal notations, "relative" for the relative offsets without a base datetime
+	Rule string
+	// TimeRule the original rule matched the time part after the date, empty if no time part
+	TimeRule string
+	// Skipped the words skipped by the fuzzy parsing
+	Skipped []string
+}
+
+var (
+	// the keys of the format result from the smallest field
+	precisionKeys = []struct {
+		precision Precision
+		keys      []string
+	}{
+		{PrecisionFraction, []string{"frac"}},
+		{PrecisionSecond, []string{"II", "IIA"}},
+		{PrecisionMinute, []string{"MN", "MNA"}},
+		{PrecisionHour, []string{"HH", "hh"}},
+		{PrecisionDay, []string{"DD", "dd", "l", "D"}},
+		{PrecisionMonth, []string{"MM", "mm", "M", "m", "hm"}},
+		{PrecisionQuarter, []string{"Q"}},
+		{PrecisionHalfYear, []string{"HY"}},
+		{PrecisionYear, []string{"YY", "yy", "y"}},
+	}
+	// the precisions of the relative offset units
+	relativeUnitPrecisions = map[string]Precision{
+		"sec":       PrecisionSecond,
+		"second":    PrecisionSecond,
+		"min":       PrecisionMinute,
+		"minute":    PrecisionMinute,
+		"hour":      PrecisionHour,
+		"day":       PrecisionDay,
+		"week":      PrecisionDay,
+		"fortnight": PrecisionDay,
+		"month":     PrecisionMonth,
+		"year":      PrecisionYear,
+	}
+)
+
+// Parse parse the datetime string like 'DateTime', and report which fields are present in the string
+// e.g. "June 2008" is parsed with the precision 'PrecisionMonth', the missing day is taken from now
+func Parse(value string, opts ...Option) (*ParseResult, error) {
+	o := makeOptions(opts)
+	value = strings.TrimSpace(value)
+	// the fiscal notations
+	if o.fiscal != nil {
+		if date, ok := o.fiscal.parse(value); ok {
+			precision := PrecisionYear
+			if date.Quarter > 0 {
+				precision = PrecisionQuarter
+			}
+			return &ParseResult{
+				Time:      o.fiscal.Start(date, o.location),
+				Precision: precision,
+				HasDate:   true,
+				Rule:      "fiscal",
+			}, nil
+		}
+	}
+	// the relative offsets suffix, the precision is the smaller one of the base and the offsets
+	if base, offsets, ok := splitRelativeSuffix(value); ok {
+		result := &ParseResult{
+			Time:      time.Now().In(o.location),
+			Precision: PrecisionFraction,
+			Rule:      "relative",
+		}
+		if base != "" {
+			var err error
+			if result, err = Parse(base, opts...); err != nil {
+				return nil, err
+			}
+		}
+		for _, matchs := range relativeSuffixItemRule.FindAllStringSubmatch(offsets, -1) {
+			precision := PrecisionDay
+			if matchs[2] == "" {
+				precision = relativeUnitPrecisions[strings.ToLower(matchs[3])]
+			}
+			if precision > result.Precision {
+				result.Precision = precision
+			}
+		}
+		result.Time = addRelativeOffsets(result.Time, offsets, o)
+		return result, nil
+	}
+	lasts, patterns, err := matchOptionsDateTime(value, o)
+	var skipped []string
+	if err != nil && o.fuzzy {
+		lasts, patterns, skipped, err = matchFuzzyDateTime(value, o)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, err := makeParseResult(lasts, patterns, o)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = skipped
+	return result, nil
+}
+
+// make the parse result by the format result and the matched patterns
+func makeParseResult(lasts FormatResult, patterns []*Pattern, o *options) (*ParseResult, error) {
+	result := &ParseResult{
+		Precision: precisionOf(lasts),
+		HasDate:   noEmptyField(lasts, "YY", "yy", "y", "MM", "mm", "M", "m", "hm", "DD", "dd", "l", "D", "Q", "HY") != "",
+		HasTime:   noEmptyField(lasts, "HH", "hh", "MN", "MNA", "II", "IIA", "frac", "meridian") != "",
+		HasZone:   noEmptyField(lasts, "tz", "tz_plain", "tzcorrection", "tzcorrection_plain") != "",
+	}
+	// the precision is taken before filling the missing fields
+	if o.fillDate {
+		fillDateFields(lasts)
+	}
+	t, err := makeFormatDateTime(lasts, o)
+	if err != nil {
+		return nil, err
+	}
+	result.Time = t
+	if len(patterns) > 0 {
+		result.Rule = patterns[0].Original
+	}
+	if len(patterns) > 1 {
+		result.TimeRule = patterns[1].Original
+	}
+	return result, nil
+}
+
+// get the precision of the smallest field in the format result
+func precisionOf(result FormatResult) Precision {
+	for _, item := range precisionKeys {
+		if noEmptyField(result, item.keys...) != "" {
+			return item.precision
+		}
+	}
+	return PrecisionUnknown
+}
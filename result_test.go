@@ -0,0 +1,87 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	utc := WithLocation(time.UTC)
+	cases := map[string]Precision{
+		"1978":                              PrecisionYear,
+		"2021H1":                            PrecisionHalfYear,
+		"2021-Q3":                           PrecisionQuarter,
+		"June 2008":                         PrecisionMonth,
+		"2008-06":                           PrecisionMonth,
+		"2008-06-30":                        PrecisionDay,
+		"2008-06-30 4pm":                    PrecisionHour,
+		"2008-06-30 16:08":                  PrecisionMinute,
+		"2008-06-30T16:08:37":               PrecisionSecond,
+		"2008-06-30 16:08:37.81412":         PrecisionFraction,
+		"2008-06-30T16:08:37Z":              PrecisionSecond,
+		"Mon Jan 02 15:04:05 2006":          PrecisionSecond,
+		"2008-06-30 +2 hours":               PrecisionHour,
+		"2008-06 +1 month +3 days":          PrecisionDay,
+		"Europe/Paris":                      PrecisionUnknown,
+		"2006-01-02 15:04 +0800":            PrecisionMinute,
+		"02 Jan 06 15:04 -0700":             PrecisionMinute,
+		"2006-01-02 15:04:05.999 -0700 MST": PrecisionFraction,
+	}
+	for value, expect := range cases {
+		if result, err := Parse(value, utc); err == nil {
+			assert.Equal(t, result.Precision, expect, value)
+		} else {
+			assert.Fail(t, "Parse '"+value+"' fail")
+		}
+	}
+	// the flags and the rules
+	if result, err := Parse("June 2008", utc); err == nil {
+		assert.Equal(t, result.Time.Format("2006-01"), "2008-06")
+		assert.True(t, result.HasDate)
+		assert.False(t, result.HasTime)
+		assert.False(t, result.HasZone)
+		assert.Equal(t, result.Rule, "(?i)^${m}[ \\t.-]*${YY}")
+		assert.Equal(t, result.TimeRule, "")
+		assert.Equal(t, result.Precision.String(), "month")
+	} else {
+		assert.Fail(t, "Parse 'June 2008' fail")
+	}
+	if result, err := Parse("2008-06-30 19:19 +0430", utc); err == nil {
+		assert.Equal(t, result.Time.Format("2006-01-02 15:04"), "2008-06-30 14:49")
+		assert.True(t, result.HasDate)
+		assert.True(t, result.HasTime)
+		assert.True(t, result.HasZone)
+		assert.Equal(t, result.Rule, "^[+-]?${YY}-${MM}-${DD}")
+		assert.Equal(t, result.TimeRule, "(?i)^t?${HH}[.:]${MNA}[ \\t]?(?:${tzcorrection}|${tz})$")
+	} else {
+		assert.Fail(t, "Parse datetime with zone fail")
+	}
+	if result, err := Parse("4:08 am", utc); err == nil {
+		assert.False(t, result.HasDate)
+		assert.True(t, result.HasTime)
+		assert.Equal(t, result.Precision, PrecisionMinute)
+	} else {
+		assert.Fail(t, "Parse time fail")
+	}
+	// the relative offsets without a base
+	if result, err := Parse("+1 day", utc); err == nil {
+		assert.Equal(t, result.Precision, PrecisionFraction)
+		assert.Equal(t, result.Rule, "relative")
+	} else {
+		assert.Fail(t, "Parse relative offsets fail")
+	}
+	// the fiscal notations
+	fiscal := WithFiscalCalendar(&FiscalCalendar{StartMonth: time.April})
+	if result, err := Parse("FY2022 Q1", utc, fiscal); err == nil {
+		assert.Equal(t, result.Time.Format("2006-01-02"), "2021-04-01")
+		assert.Equal(t, result.Precision, PrecisionQuarter)
+		assert.Equal(t, result.Rule, "fiscal")
+	} else {
+		assert.Fail(t, "Parse fiscal quarter fail")
+	}
+	if _, err := Parse("wrong", utc); err == nil {
+		assert.Fail(t, "Parse wrong datetime ok")
+	}
+}
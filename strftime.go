@@ -0,0 +1,498 @@
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// strftimeField a conversion of the strftime directive
+type strftimeField struct {
+	// the numeric value of the field
+	number func(t time.Time) int
+	// the default width and padding of the number
+	width int
+	pad   byte
+	// the textual value of the field
+	text func(t time.Time) string
+}
+
+// use the format character of 'DateFormat'
+func phpField(ch byte) strftimeField {
+	return strftimeField{
+		text: func(t time.Time) string {
+			value, _ := formatChar(t, ch)
+			return value
+		},
+	}
+}
+
+// a numeric field padding with zero
+func zeroField(width int, number func(t time.Time) int) strftimeField {
+	return strftimeField{
+		number: number,
+		width:  width,
+		pad:    '0',
+	}
+}
+
+// a numeric field padding with space
+func spaceField(width int, number func(t time.Time) int) strftimeField {
+	return strftimeField{
+		number: number,
+		width:  width,
+		pad:    ' ',
+	}
+}
+
+// a field combined by other directives
+func composeField(format string) strftimeField {
+	return strftimeField{
+		text: func(t time.Time) string {
+			return strftime(t, format)
+		},
+	}
+}
+
+// get the hour of the 12-hour clock
+func hour12(t time.Time) int {
+	if hour := t.Hour() % 12; hour != 0 {
+		return hour
+	}
+	return 12
+}
+
+var (
+	// the composed directives, the same as the 'C' or 'POSIX' locale
+	strftimeComposes = map[byte]string{
+		'c': "%a %b %e %H:%M:%S %Y",
+		'D': "%m/%d/%y",
+		'F': "%Y-%m-%d",
+		'r': "%I:%M:%S %p",
+		'R': "%H:%M",
+		'T': "%H:%M:%S",
+		'x': "%m/%d/%y",
+		'X': "%H:%M:%S",
+	}
+	// the strftime directives
+	strftimeFields = map[byte]strftimeField{
+		// weekday and month names
+		'a': phpField('D'),
+		'A': phpField('l'),
+		'b': phpField('M'),
+		'h': phpField('M'),
+		'B': phpField('F'),
+		// year
+		'C': zeroField(2, func(t time.Time) int { return t.Year() / 100 }),
+		'y': zeroField(2, func(t time.Time) int { return t.Year() % 100 }),
+		'Y': zeroField(4, func(t time.Time) int { return t.Year() }),
+		'G': zeroField(4, func(t time.Time) int {
+			year, _ := t.ISOWeek()
+			return year
+		}),
+		'g': zeroField(2, func(t time.Time) int {
+			year, _ := t.ISOWeek()
+			return year % 100
+		}),
+		// month
+		'm': zeroField(2, func(t time.Time) int { return int(t.Month()) }),
+		// day
+		'd': zeroField(2, func(t time.Time) int { return t.Day() }),
+		'e': spaceField(2, func(t time.Time) int { return t.Day() }),
+		'j': zeroField(3, func(t time.Time) int { return t.YearDay() }),
+		// weekday, '%u' from monday(1) to sunday(7), '%w' from sunday(0) to saturday(6)
+		'u': zeroField(1, func(t time.Time) int { return (int(t.Weekday())+6)%7 + 1 }),
+		'w': zeroField(1, func(t time.Time) int { return int(t.Weekday()) }),
+		// week number, '%U' start from sunday, '%W' start from monday, '%V' ISO-8601
+		'U': zeroField(2, func(t time.Time) int { return (t.YearDay() + 6 - int(t.Weekday())) / 7 }),
+		'W': zeroField(2, func(t time.Time) int { return (t.YearDay() + 6 - (int(t.Weekday())+6)%7) / 7 }),
+		'V': zeroField(2, func(t time.Time) int {
+			_, week := t.ISOWeek()
+			return week
+		}),
+		// hours
+		'H': zeroField(2, func(t time.Time) int { return t.Hour() }),
+		'k': spaceField(2, func(t time.Time) int { return t.Hour() }),
+		'I': zeroField(2, hour12),
+		'l': spaceField(2, hour12),
+		'p': phpField('A'),
+		'P': phpField('a'),
+		// minutes and seconds
+		'M': zeroField(2, func(t time.Time) int { return t.Minute() }),
+		'S': zeroField(2, func(t time.Time) int { return t.Second() }),
+		// microseconds as python, nanoseconds as shell 'date'
+		'f': zeroField(6, func(t time.Time) int { return t.Nanosecond() / 1e3 }),
+		'N': zeroField(9, func(t time.Time) int { return t.Nanosecond() }),
+		// seconds since the unix epoch
+		's': strftimeField{
+			text: func(t time.Time) string {
+				return strconv.FormatInt(t.Unix(), 10)
+			},
+		},
+		// timezone
+		'z': strftimeField{
+			text: func(t time.Time) string {
+				return t.Format("-0700")
+			},
+		},
+		'Z': strftimeField{
+			text: func(t time.Time) string {
+				return t.Format("MST")
+			},
+		},
+		// characters
+		'n': strftimeField{
+			text: func(t time.Time) string {
+				return "\n"
+			},
+		},
+		't': strftimeField{
+			text: func(t time.Time) string {
+				return "\t"
+			},
+		},
+	}
+)
+
+// the flags and width of a directive, e.g. "%-d", "%_3j", "%^a"
+type strftimeSpec struct {
+	flags     string
+	width     int
+	hasWidth  bool
+	directive byte
+	colon     bool
+}
+
+// read the directive spec after the '%'
+// return the spec and the index of the directive
+func readStrftimeSpec(format string, i int) (strftimeSpec, int, bool) {
+	spec := strftimeSpec{}
+	for ; i < len(format) && strings.IndexByte("-_0^#", format[i]) >= 0; i++ {
+		spec.flags += string(format[i])
+	}
+	start := i
+	for ; i < len(format) && isDigit(format[i]); i++ {
+	}
+	if i > start {
+		spec.width, _ = strconv.Atoi(format[start:i])
+		spec.hasWidth = true
+	}
+	// glibc's alternative modifiers
+	if i < len(format) && (format[i] == 'E' || format[i] == 'O') {
+		i++
+	}
+	// the '%:z' of shell 'date'
+	if i < len(format) && format[i] == ':' {
+		spec.colon = true
+		i++
+	}
+	if i >= len(format) {
+		return spec, i, false
+	}
+	spec.directive = format[i]
+	return spec, i, true
+}
+
+// format a directive with the flags
+func (spec strftimeSpec) format(t time.Time, field strftimeField) string {
+	var value string
+	if field.number != nil {
+		num := field.number(t)
+		width, pad := field.width, field.pad
+		if spec.hasWidth {
+			width = spec.width
+		}
+		for _, flag := range spec.flags {
+			switch flag {
+			case '-':
+				pad = 0
+			case '_':
+				pad = ' '
+			case '0':
+				pad = '0'
+			}
+		}
+		value = strconv.Itoa(num)
+		if num < 0 {
+			value = value[1:]
+		}
+		if pad != 0 && len(value) < width {
+			value = strings.Repeat(string(pad), width-len(value)) + value
+		}
+		if num < 0 {
+			value = "-" + value
+		}
+	} else {
+		value = field.text(t)
+		if spec.directive == 'z' && spec.colon {
+			value = value[:3] + ":" + value[3:]
+		}
+		if spec.hasWidth && len(value) < spec.width {
+			pad := " "
+			if strings.ContainsRune(spec.flags, '0') {
+				pad = "0"
+			}
+			value = strings.Repeat(pad, spec.width-len(value)) + value
+		}
+	}
+	for _, flag := range spec.flags {
+		switch flag {
+		case '^':
+			value = strings.ToUpper(value)
+		case '#':
+			// swap the case
+			if value == strings.ToUpper(value) {
+				value = strings.ToLower(value)
+			} else {
+				value = strings.ToUpper(value)
+			}
+		}
+	}
+	return value
+}
+
+// format the time by the strftime format
+func strftime(t time.Time, format string) string {
+	var result strings.Builder
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		if ch != '%' {
+			result.WriteByte(ch)
+			continue
+		}
+		spec, next, ok := readStrftimeSpec(format, i+1)
+		if !ok {
+			// the end of the format, keep the characters
+			result.WriteString(format[i:])
+			break
+		}
+		if spec.directive == '%' {
+			result.WriteByte('%')
+		} else if compose, ok := strftimeComposes[spec.directive]; ok {
+			result.WriteString(spec.format(t, composeField(compose)))
+		} else if field, ok := strftimeFields[spec.directive]; ok {
+			result.WriteString(spec.format(t, field))
+		} else {
+			// unknown directive, keep it
+			result.WriteString(format[i : next+1])
+		}
+		i = next
+	}
+	return result.String()
+}
+
+// Strftime format the time by the C-style strftime format, e.g. "%Y-%m-%d %H:%M:%S %z"
+// the padding modifiers '-', '_', '0' and the case modifiers '^', '#' are supported
+func Strftime(target interface{}, format string) (string, error) {
+	var timeTarget time.Time
+	if cur, ok := target.(time.Time); ok {
+		timeTarget = cur
+	} else {
+		if cur, err := DateTime(target); err == nil {
+			timeTarget = cur
+		} else {
+			return "", err
+		}
+	}
+	return strftime(timeTarget, format), nil
+}
+
+// parse the numeric field after the leading spaces
+func spaced(parser fieldParser) fieldParser {
+	return func(s *formatScanner, f *dateFields) bool {
+		for !s.done() && s.value[s.pos] == ' ' {
+			s.pos++
+		}
+		return parser(s, f)
+	}
+}
+
+// parse a week number, 'mode' is the same as 'dateFields.weekMode'
+func parseWeekNumber(mode byte) fieldParser {
+	return func(s *formatScanner, f *dateFields) bool {
+		week, _, ok := s.number(1, 2)
+		f.week = week
+		f.weekMode = mode
+		f.flags |= hasWeek
+		return ok
+	}
+}
+
+var (
+	// the strptime directives
+	strptimeParsers = map[byte]fieldParser{
+		// weekday and month names
+		'a': parseWeekdayName,
+		'A': parseWeekdayName,
+		'b': parseMonthName,
+		'B': parseMonthName,
+		'h': parseMonthName,
+		// year, the two digits year of POSIX: 69-99 => 1969-1999, 00-68 => 2000-2068
+		'y': spaced(func(s *formatScanner, f *dateFields) bool {
+			year, _, ok := s.number(1, 2)
+			if year < 69 {
+				year += 2000
+			} else {
+				year += 1900
+			}
+			f.year = year
+			f.flags |= hasYear
+			return ok
+		}),
+		'Y': spaced(parseYear),
+		'G': spaced(parseYear),
+		// month
+		'm': spaced(parseMonth),
+		// day
+		'd': spaced(parseDay),
+		'e': spaced(parseDay),
+		'j': spaced(func(s *formatScanner, f *dateFields) bool {
+			day, _, ok := s.number(1, 3)
+			f.yearDay = day - 1
+			f.flags |= hasYearDay
+			return ok && day > 0
+		}),
+		// weekday
+		'u': spaced(func(s *formatScanner, f *dateFields) bool {
+			weekday, _, ok := s.number(1, 1)
+			f.weekday = weekday % 7
+			f.flags |= hasWeekday
+			return ok && weekday >= 1 && weekday <= 7
+		}),
+		'w': spaced(parseNumberField(1, 1, hasWeekday, func(f *dateFields) *int { return &f.weekday })),
+		// week number
+		'U': spaced(parseWeekNumber('U')),
+		'W': spaced(parseWeekNumber('W')),
+		'V': spaced(parseWeekNumber(0)),
+		// hours
+		'H': spaced(parseHour),
+		'k': spaced(parseHour),
+		'I': spaced(parseHour),
+		'l': spaced(parseHour),
+		'p': parseMeridian,
+		'P': parseMeridian,
+		// minutes and seconds
+		'M': spaced(parseNumberField(1, 2, hasMinute, func(f *dateFields) *int { return &f.minute })),
+		'S': spaced(parseNumberField(1, 2, hasSecond, func(f *dateFields) *int { return &f.second })),
+		'f': parseFraction(6),
+		'N': parseFraction(9),
+		// timestamp
+		's': parseTimestamp,
+		// timezone
+		'z': parseZone,
+		'Z': parseZoneAbbr,
+	}
+	// the offsets of the timezone abbreviations printed by '%Z' which are not the location names,
+	// "CST" is the north american central time like php, the ambiguous ones like "IST" are not included
+	zoneAbbrOffsets = map[string]int{
+		"EDT":  -4 * 3600,
+		"CST":  -6 * 3600,
+		"CDT":  -5 * 3600,
+		"MDT":  -6 * 3600,
+		"PST":  -8 * 3600,
+		"PDT":  -7 * 3600,
+		"AKST": -9 * 3600,
+		"AKDT": -8 * 3600,
+		"HST":  -10 * 3600,
+		"BST":  1 * 3600,
+		"WEST": 1 * 3600,
+		"CET":  1 * 3600,
+		"CEST": 2 * 3600,
+		"EEST": 3 * 3600,
+		"MSK":  3 * 3600,
+		"HKT":  8 * 3600,
+		"SGT":  8 * 3600,
+		"AWST": 8 * 3600,
+		"JST":  9 * 3600,
+		"KST":  9 * 3600,
+		"ACST": 9*3600 + 1800,
+		"ACDT": 10*3600 + 1800,
+		"AEST": 10 * 3600,
+		"AEDT": 11 * 3600,
+		"NZST": 12 * 3600,
+		"NZDT": 13 * 3600,
+	}
+)
+
+// parse the timezone of '%Z', the common abbreviations are the fixed zones, e.g. "CEST" is +02:00
+func parseZoneAbbr(s *formatScanner, f *dateFields) bool {
+	if parseZone(s, f) {
+		return true
+	}
+	if abbr := zoneNameRule.FindString(s.rest()); abbr != "" {
+		if offset, ok := zoneAbbrOffsets[strings.ToUpper(abbr)]; ok {
+			s.pos += len(abbr)
+			f.location = time.FixedZone(strings.ToUpper(abbr), offset)
+			f.flags |= hasZone
+			return true
+		}
+	}
+	return false
+}
+
+// parse the value by the strptime format
+func strptime(format string, s *formatScanner, fields *dateFields) error {
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			// whitespaces match zero or more whitespaces
+			for !s.done() && strings.IndexByte(" \t\n", s.value[s.pos]) >= 0 {
+				s.pos++
+			}
+		case ch == '%':
+			spec, next, ok := readStrftimeSpec(format, i+1)
+			if !ok {
+				return fmt.Errorf("the format ends with an incomplete directive: '%s'", format)
+			}
+			i = next
+			directive := spec.directive
+			if directive == 'n' || directive == 't' {
+				for !s.done() && strings.IndexByte(" \t\n", s.value[s.pos]) >= 0 {
+					s.pos++
+				}
+			} else if directive == '%' {
+				if s.done() || s.value[s.pos] != '%' {
+					return fmt.Errorf("the character at position %d doesn't match '%%': '%s'", s.pos, s.value)
+				}
+				s.pos++
+			} else if compose, ok := strftimeComposes[directive]; ok {
+				if err := strptime(compose, s, fields); err != nil {
+					return err
+				}
+			} else if parser, ok := strptimeParsers[directive]; ok {
+				if !parser(s, fields) {
+					return fmt.Errorf("wrong value for the directive '%%%c' at position %d: '%s'", directive, s.pos, s.value)
+				}
+			} else {
+				return fmt.Errorf("unsupported directive '%%%c'", directive)
+			}
+		default:
+			if s.done() || s.value[s.pos] != ch {
+				return fmt.Errorf("the character at position %d doesn't match '%c': '%s'", s.pos, ch, s.value)
+			}
+			s.pos++
+		}
+	}
+	return nil
+}
+
+// Strptime parse the value by the C-style strptime format, e.g. "%Y-%m-%d %H:%M:%S %z"
+// the fields not in the format will use the current time's value like 'ParseFormat'
+func Strptime(format, value string, opts ...Option) (time.Time, error) {
+	o := makeOptions(opts)
+	fields := dateFields{}
+	s := &formatScanner{value: value}
+	if err := strptime(format, s, &fields); err != nil {
+		return time.Time{}, err
+	}
+	if !s.done() {
+		return time.Time{}, fmt.Errorf("trailing data '%s': '%s'", s.rest(), value)
+	}
+	lastTime, err := fields.toTime(o.location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return lastTime.In(o.location), nil
+}
@@ -0,0 +1,156 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrftime(t *testing.T) {
+	curTime := makeTestTime()
+	shanghai := time.Date(2021, time.September, 5, 18, 7, 6, 0, localLocation)
+	if result, err := Strftime(curTime, "%Y-%m-%d %H:%M:%S"); err == nil {
+		assert.Equal(t, result, "2021-09-05 18:07:06")
+	} else {
+		assert.Fail(t, "Strftime '%Y-%m-%d %H:%M:%S' fail")
+	}
+	// names
+	if result, err := Strftime(curTime, "%a %A %b %B %h"); err == nil {
+		assert.Equal(t, result, "Sun Sunday Sep September Sep")
+	} else {
+		assert.Fail(t, "Strftime names fail")
+	}
+	// day of the year, weeks
+	if result, err := Strftime(curTime, "%j %U %W %V %G %g %u %w"); err == nil {
+		assert.Equal(t, result, "248 36 35 35 2021 21 7 0")
+	} else {
+		assert.Fail(t, "Strftime weeks fail")
+	}
+	// 12-hour clock
+	if result, err := Strftime(curTime, "%I %l %p %P"); err == nil {
+		assert.Equal(t, result, "06  6 PM pm")
+	} else {
+		assert.Fail(t, "Strftime 12-hour fail")
+	}
+	// padding modifiers
+	if result, err := Strftime(curTime, "%-d|%e|%_m|%-m|%3d|%^a|%#B|%-j"); err == nil {
+		assert.Equal(t, result, "5| 5| 9|9|005|SUN|SEPTEMBER|248")
+	} else {
+		assert.Fail(t, "Strftime padding modifiers fail")
+	}
+	// composed directives
+	if result, err := Strftime(curTime, "%c|%D|%F|%T|%R|%r"); err == nil {
+		assert.Equal(t, result, "Sun Sep  5 18:07:06 2021|09/05/21|2021-09-05|18:07:06|18:07|06:07:06 PM")
+	} else {
+		assert.Fail(t, "Strftime composed directives fail")
+	}
+	// timezone and timestamp
+	if result, err := Strftime(shanghai, "%z %:z %Z %s"); err == nil {
+		assert.Equal(t, result, "+0800 +08:00 CST 1630836426")
+	} else {
+		assert.Fail(t, "Strftime timezone fail")
+	}
+	// fraction, literal
+	if result, err := Strftime(curTime, "%S.%f %N %% %q"); err == nil {
+		assert.Equal(t, result, "06.012345 012345678 % %q")
+	} else {
+		assert.Fail(t, "Strftime fraction fail")
+	}
+}
+
+func TestStrptime(t *testing.T) {
+	if date, err := Strptime("%Y-%m-%d %H:%M:%S", "2021-09-05 18:07:06"); err == nil {
+		assert.True(t, isSameDate(&date, YMDHis))
+	} else {
+		assert.Fail(t, "Strptime '%Y-%m-%d %H:%M:%S' fail")
+	}
+	// names and 12-hour clock
+	if date, err := Strptime("%A, %B %e %Y %I:%M %p", "Sunday, September  5 2021 06:07 PM"); err == nil {
+		assert.True(t, isSameDate(&date, YMD|HOUR|MINUTE))
+	} else {
+		assert.Fail(t, "Strptime names fail")
+	}
+	// padding modifiers are ignored
+	if date, err := Strptime("%-d/%-m/%y", "5/9/21"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "Strptime '%-d/%-m/%y' fail")
+	}
+	// composed directives, fraction
+	if date, err := Strptime("%F %T.%f", "2021-09-05 18:07:06.012345"); err == nil {
+		assert.True(t, isSameDate(&date, YMDHis))
+		assert.Equal(t, date.Nanosecond(), 12345000)
+	} else {
+		assert.Fail(t, "Strptime '%F %T.%f' fail")
+	}
+	// day of the year
+	if date, err := Strptime("%Y %j", "2021 248"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "Strptime '%j' fail")
+	}
+	// week numbers
+	if date, err := Strptime("%Y %U %w", "2021 36 0"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "Strptime '%U' fail")
+	}
+	if date, err := Strptime("%Y %W %u", "2021 35 7"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "Strptime '%W' fail")
+	}
+	if date, err := Strptime("%G-W%V-%u", "2021-W35-7"); err == nil {
+		assert.True(t, isSameDate(&date, YMD))
+	} else {
+		assert.Fail(t, "Strptime '%V' fail")
+	}
+	// timezone and timestamp
+	if date, err := Strptime("%Y-%m-%dT%H:%M:%S%z", "2021-09-05T18:07:06+0800"); err == nil {
+		assert.Equal(t, date.Unix(), int64(1630836426))
+	} else {
+		assert.Fail(t, "Strptime '%z' fail")
+	}
+	if date, err := Strptime("%s", "1630836426", WithLocation(time.UTC)); err == nil {
+		assert.Equal(t, date.Hour(), 10)
+	} else {
+		assert.Fail(t, "Strptime '%s' fail")
+	}
+	// the timezone abbreviations of '%Z' are parsed back
+	for _, name := range []string{"Europe/Berlin", "America/Los_Angeles", "America/New_York"} {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		date := time.Date(2021, time.September, 5, 18, 7, 6, 0, location)
+		format := "%Y-%m-%d %H:%M:%S %Z"
+		if value, err := Strftime(date, format); err == nil {
+			if parsed, err := Strptime(format, value); err == nil {
+				assert.Equal(t, parsed.Unix(), date.Unix(), value)
+			} else {
+				assert.Fail(t, "Strptime '%Z' of '"+value+"' fail")
+			}
+		} else {
+			assert.Fail(t, "Strftime '%Z' fail")
+		}
+	}
+	// the ambiguous abbreviations
+	if _, err := Strptime("%Y-%m-%d %H:%M %Z", "2021-09-05 18:07 IST"); err == nil {
+		assert.Fail(t, "Strptime the ambiguous '%Z' ok")
+	}
+	// the abbreviations are only for '%Z'
+	if _, err := ParseFormat("Y-m-d H:i T", "2021-09-05 18:07 CEST"); err == nil {
+		assert.Fail(t, "ParseFormat the abbreviation 'T' ok")
+	}
+	// wrong values
+	if _, err := Strptime("%Y-%m-%d", "2021-09"); err == nil {
+		assert.Fail(t, "Strptime missing data ok")
+	}
+	if _, err := Strptime("%Y-%m-%d %C", "2021-09-05 20"); err == nil {
+		assert.Fail(t, "Strptime unsupported directive ok")
+	}
+	if _, err := Strptime("%Y-%m", "2021-09-05"); err == nil {
+		assert.Fail(t, "Strptime trailing data ok")
+	}
+}